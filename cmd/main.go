@@ -1,16 +1,29 @@
 package main
 
 import (
+	"context"
+
 	"github.com/esc-chula/intania-888-backend/cmd/server"
 	"github.com/esc-chula/intania-888-backend/internal/domain/auth"
 	"github.com/esc-chula/intania-888-backend/internal/domain/bill"
+	"github.com/esc-chula/intania-888-backend/internal/domain/coinflip"
 	"github.com/esc-chula/intania-888-backend/internal/domain/color"
+	"github.com/esc-chula/intania-888-backend/internal/domain/crash"
+	"github.com/esc-chula/intania-888-backend/internal/domain/dice"
 	"github.com/esc-chula/intania-888-backend/internal/domain/event"
+	"github.com/esc-chula/intania-888-backend/internal/domain/leaderboard"
 	"github.com/esc-chula/intania-888-backend/internal/domain/match"
 	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/domain/moderation"
+	"github.com/esc-chula/intania-888-backend/internal/domain/notification"
+	"github.com/esc-chula/intania-888-backend/internal/domain/quest"
+	"github.com/esc-chula/intania-888-backend/internal/domain/season"
 	"github.com/esc-chula/intania-888-backend/internal/domain/sporttype"
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
 	"github.com/esc-chula/intania-888-backend/internal/domain/stakemine"
+	"github.com/esc-chula/intania-888-backend/internal/domain/teampool"
 	"github.com/esc-chula/intania-888-backend/internal/domain/user"
+	"github.com/esc-chula/intania-888-backend/internal/domain/wallet"
 	"github.com/esc-chula/intania-888-backend/pkg/cache"
 	"github.com/esc-chula/intania-888-backend/pkg/config"
 	"github.com/esc-chula/intania-888-backend/pkg/database"
@@ -42,20 +55,32 @@ func main() {
 	userSvc := user.NewUserService(userRepo, db, logger.Named("UserSvc"))
 	userHttp := user.NewUserHttpHandler(userSvc)
 
-	authRepo := auth.NewAuthRepository(*cache)
-	authSvc := auth.NewAuthService(authRepo, userRepo, cfg, logger.Named("AuthSvc"), oauth.NewGoogleOAuthClient(oauthConfig, logger))
-	authHttp := auth.NewAuthHttpHandler(authSvc)
-
 	midRepo := middleware.NewMiddlewareRepository(db)
 	midSvc := middleware.NewMiddlewareService(midRepo, cache, logger.Named("MiddlewareSvc"), cfg)
 	midHttp := middleware.NewMiddlewareHttpHandler(midSvc, logger)
 
+	authRepo := auth.NewAuthRepository(*cache)
+	authSvc := auth.NewAuthService(authRepo, userRepo, midSvc, cfg, logger.Named("AuthSvc"), oauth.NewGoogleOAuthClient(oauthConfig, logger))
+	authHttp := auth.NewAuthHttpHandler(authSvc)
+
+	moderationRepo := moderation.NewModerationRepository(db, *cache)
+	moderationSvc := moderation.NewModerationService(moderationRepo, authSvc, logger.Named("ModerationSvc"))
+	moderationHttp := moderation.NewModerationHttpHandler(moderationSvc)
+
+	questRepo := quest.NewQuestRepository(db)
+	questSvc := quest.NewQuestService(questRepo, db, logger.Named("QuestSvc"))
+	questHttp := quest.NewQuestHttpHandler(questSvc)
+
 	billRepo := bill.NewBillRepository(db)
-	billSvc := bill.NewBillService(billRepo, userRepo, db, logger.Named("BillSvc"))
+	billSvc := bill.NewBillService(billRepo, userRepo, questSvc, db, logger.Named("BillSvc"))
 	billHttp := bill.NewBillHttpHandler(billSvc)
 
+	teamPoolRepo := teampool.NewTeamPoolRepository(db)
+	teamPoolSvc := teampool.NewTeamPoolService(teamPoolRepo, cfg, logger.Named("TeamPoolSvc"))
+	teamPoolHttp := teampool.NewTeamPoolHttpHandler(teamPoolSvc)
+
 	matchRepo := match.NewMatchRepository(db)
-	matchSvc := match.NewMatchService(matchRepo, logger.Named("MatchSvc"))
+	matchSvc := match.NewMatchService(matchRepo, questSvc, teamPoolSvc, logger.Named("MatchSvc"))
 	matchHttp := match.NewMatchHttpHandler(matchSvc)
 
 	colorRepo := color.NewColorRepository(db)
@@ -63,12 +88,42 @@ func main() {
 	colorHttp := color.NewColorHttpHandler(colorSvc)
 
 	eventRepo := event.NewEventRepository(db, *cache)
-	eventSvc := event.NewEventService(eventRepo, userRepo, cfg, logger)
+	eventSvc := event.NewEventService(eventRepo, userRepo, questSvc, cfg, logger)
 	eventHttp := event.NewEventHttpHandler(eventSvc)
 
-	stakeMineRepo := stakemine.NewStakeMineRepository(db)
-	stakeMineSvc := stakemine.NewStakeMineService(stakeMineRepo, db, logger.Named("StakeMineSvc"))
+	notificationRepo := notification.NewNotificationRepository(db)
+	notificationSvc := notification.NewNotificationService(notificationRepo, logger.Named("NotificationSvc"))
+	notificationHttp := notification.NewNotificationHttpHandler(notificationSvc)
+
+	stakeMineRepo := stakemine.NewStakeMineRepository(db, *cache)
+	stakeMineSvc := stakemine.NewStakeMineService(stakeMineRepo, db, notificationSvc, questSvc, cfg, logger.Named("StakeMineSvc"))
 	stakeMineHttp := stakemine.NewStakeMineHttpHandler(stakeMineSvc)
+
+	crashRepo := crash.NewCrashRepository(db)
+	crashSvc := crash.NewCrashService(crashRepo, db, cfg, logger.Named("CrashSvc"))
+	crashHttp := crash.NewCrashHttpHandler(crashSvc)
+
+	stakeGameRepo := stakegame.NewGameRepository(db)
+	stakeGameSvc := stakegame.NewGameService(stakeGameRepo, db, logger.Named("StakeGameSvc"))
+
+	coinflipSvc := coinflip.NewCoinflipService(stakeGameSvc, cfg, logger.Named("CoinflipSvc"))
+	coinflipHttp := coinflip.NewCoinflipHttpHandler(coinflipSvc)
+
+	diceSvc := dice.NewDiceService(stakeGameSvc, cfg, logger.Named("DiceSvc"))
+	diceHttp := dice.NewDiceHttpHandler(diceSvc)
+
+	leaderboardRepo := leaderboard.NewLeaderboardRepository(db, *cache)
+	leaderboardSvc := leaderboard.NewLeaderboardService(leaderboardRepo, cfg, logger.Named("LeaderboardSvc"))
+	leaderboardHttp := leaderboard.NewLeaderboardHttpHandler(leaderboardSvc)
+
+	walletRepo := wallet.NewWalletRepository(db)
+	walletSvc := wallet.NewWalletService(walletRepo, db, cfg, logger.Named("WalletSvc"))
+	walletHttp := wallet.NewWalletHttpHandler(walletSvc)
+
+	seasonRepo := season.NewSeasonRepository(db)
+	seasonSvc := season.NewSeasonService(seasonRepo, logger.Named("SeasonSvc"))
+	seasonHttp := season.NewSeasonHttpHandler(seasonSvc)
+
 	sportTypeRepo := sporttype.NewSportTypeRepository(db)
 	sportTypeSvc := sporttype.NewSportTypeService(sportTypeRepo, logger.Named("SportTypeSvc"))
 	sportTypeHttp := sporttype.NewSportTypeHttpHandler(sportTypeSvc)
@@ -85,6 +140,16 @@ func main() {
 	colorHttp.RegisterRoutes(router, midHttp)
 	eventHttp.RegisterRoutes(router, midHttp)
 	stakeMineHttp.RegisterRoutes(router, midHttp)
+	crashHttp.RegisterRoutes(router, midHttp)
+	coinflipHttp.RegisterRoutes(router, midHttp)
+	diceHttp.RegisterRoutes(router, midHttp)
+	notificationHttp.RegisterRoutes(router, midHttp)
+	questHttp.RegisterRoutes(router, midHttp)
+	teamPoolHttp.RegisterRoutes(router, midHttp)
+	leaderboardHttp.RegisterRoutes(router, midHttp)
+	walletHttp.RegisterRoutes(router, midHttp)
+	seasonHttp.RegisterRoutes(router, midHttp)
+	moderationHttp.RegisterRoutes(router, midHttp)
 	sportTypeHttp.RegisterRoutes(router, midHttp)
 
 	// register external API routes
@@ -92,6 +157,13 @@ func main() {
 	userHttp.RegisterExternalRoutes(externalRouter, midHttp)
 	authHttp.RegisterExternalRoutes(externalRouter, midHttp)
 
+	// start background jobs, stopped once the server shuts down
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stakeMineSvc.RunIdleGameSweeper(ctx)
+	go crashSvc.RunEngine(ctx)
+	go eventSvc.RunLotteryDraws(ctx)
+
 	// start server
 	server.Start()
 }
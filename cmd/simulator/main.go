@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakemine"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"github.com/esc-chula/intania-888-backend/utils"
+)
+
+// simulator runs the slot machine and stake mines through the same logic the API uses
+// and reports return-to-player figures, e.g.
+//
+//	go run ./cmd/simulator -spins 5000000 -games 2000000 -slot-config slot.json
+func main() {
+	spins := flag.Int("spins", 1000000, "number of simulated slot spins per balance tier")
+	games := flag.Int("games", 1000000, "number of simulated mines games per risk level")
+	bet := flag.Float64("bet", 100, "bet amount used for every spin and game")
+	slotConfigPath := flag.String("slot-config", "", "path to a slot config JSON file (defaults to the built-in config)")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of parallel workers")
+	gridSize := flag.Int("grid-size", stakemine.DefaultGridSize, "side length of the mines board")
+	bombs := flag.Int("bombs", 0, "bomb count for a custom mines board (defaults to the risk level presets)")
+	houseEdge := flag.Float64("house-edge", 0, "mines house edge (defaults to GAME_MINES_HOUSE_EDGE)")
+	flag.Parse()
+
+	houseEdgeSet := false
+	flag.Visit(func(f *flag.Flag) {
+		houseEdgeSet = houseEdgeSet || f.Name == "house-edge"
+	})
+	if !houseEdgeSet {
+		*houseEdge = config.GetConfig().GetGame().MinesHouseEdge
+	}
+	if *houseEdge < 0 || *houseEdge >= 1 {
+		log.Fatalf("House edge must be in [0, 1), got %v", *houseEdge)
+	}
+
+	if *workers < 1 {
+		*workers = 1
+	}
+
+	slotConfig, err := loadSlotConfig(*slotConfigPath)
+	if err != nil {
+		log.Fatalf("Error loading slot config: %v", err)
+	}
+
+	if *spins > 0 {
+		simulateSlots(slotConfig, *spins, *bet, *workers)
+	}
+	if *games > 0 {
+		boards, err := minesBoards(*gridSize, *bombs)
+		if err != nil {
+			log.Fatalf("Error setting up mines board: %v", err)
+		}
+		simulateMines(boards, *games, *bet, *houseEdge, *workers)
+	}
+}
+
+func loadSlotConfig(path string) (*model.SlotConfigDto, error) {
+	if path == "" {
+		return utils.DefaultSlotConfig(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config model.SlotConfigDto
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateSlotConfig(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// stats accumulates the return of each round as a multiple of the bet
+type stats struct {
+	rounds int
+	hits   int
+	sum    float64
+	sumSq  float64
+	max    float64
+}
+
+func (s *stats) add(ret float64) {
+	s.rounds++
+	s.sum += ret
+	s.sumSq += ret * ret
+	if ret > 0 {
+		s.hits++
+	}
+	if ret > s.max {
+		s.max = ret
+	}
+}
+
+func (s *stats) merge(other *stats) {
+	s.rounds += other.rounds
+	s.hits += other.hits
+	s.sum += other.sum
+	s.sumSq += other.sumSq
+	if other.max > s.max {
+		s.max = other.max
+	}
+}
+
+func (s *stats) rtp() float64 {
+	if s.rounds == 0 {
+		return 0
+	}
+	return s.sum / float64(s.rounds)
+}
+
+func (s *stats) hitFrequency() float64 {
+	if s.rounds == 0 {
+		return 0
+	}
+	return float64(s.hits) / float64(s.rounds)
+}
+
+func (s *stats) variance() float64 {
+	if s.rounds == 0 {
+		return 0
+	}
+	mean := s.rtp()
+	return s.sumSq/float64(s.rounds) - mean*mean
+}
+
+// runParallel splits rounds across workers and merges their results
+func runParallel(rounds int, workers int, newResult func() interface{}, work func(rounds int, result interface{}), merge func(result interface{})) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for w := 0; w < workers; w++ {
+		share := rounds / workers
+		if w < rounds%workers {
+			share++
+		}
+
+		wg.Add(1)
+		go func(share int) {
+			defer wg.Done()
+			result := newResult()
+			work(share, result)
+
+			mu.Lock()
+			merge(result)
+			mu.Unlock()
+		}(share)
+	}
+
+	wg.Wait()
+}
+
+type slotResult struct {
+	stats       stats
+	stealTokens int
+}
+
+func simulateSlots(config *model.SlotConfigDto, spins int, bet float64, workers int) {
+	fmt.Printf("Slot machine: %d spins per tier, bet %.2f\n", spins, bet)
+	fmt.Println("Steal tokens pay nothing directly and are counted separately.")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "tier (balance >)\tRTP\thit freq\tvariance\tstd dev\tmax win\tsteal tokens\t")
+
+	for _, tier := range config.Tiers {
+		// any balance strictly above the tier minimum selects it
+		balance := tier.MinBalance + 1
+
+		total := &slotResult{}
+		runParallel(spins, workers,
+			func() interface{} { return &slotResult{} },
+			func(rounds int, result interface{}) {
+				r := result.(*slotResult)
+				for i := 0; i < rounds; i++ {
+					reels := []string{
+						utils.GetRandomSlot(config, balance),
+						utils.GetRandomSlot(config, balance),
+						utils.GetRandomSlot(config, balance),
+					}
+
+					rule := utils.MatchSlotPaytable(config, reels)
+					if rule != nil && rule.Action == utils.SlotActionStealToken {
+						r.stealTokens++
+						r.stats.add(0)
+						continue
+					}
+
+					var multiplier float64
+					if rule != nil {
+						multiplier = roundToTwoDecimals(bet*rule.Multiplier) / bet
+					}
+					r.stats.add(multiplier)
+				}
+			},
+			func(result interface{}) {
+				r := result.(*slotResult)
+				total.stats.merge(&r.stats)
+				total.stealTokens += r.stealTokens
+			},
+		)
+
+		s := total.stats
+		fmt.Fprintf(w, "%.2f\t%.4f%%\t%.4f%%\t%.4f\t%.4f\t%.2fx\t%.4f%%\t\n",
+			tier.MinBalance,
+			s.rtp()*100,
+			s.hitFrequency()*100,
+			s.variance(),
+			math.Sqrt(s.variance()),
+			s.max,
+			float64(total.stealTokens)/float64(s.rounds)*100,
+		)
+	}
+
+	w.Flush()
+	fmt.Println()
+}
+
+type minesBoard struct {
+	name      string
+	tileCount int
+	bombCount int
+}
+
+// minesBoards returns the risk level presets for the grid size, or a single custom board when bombs is set
+func minesBoards(gridSize int, bombs int) ([]minesBoard, error) {
+	tileCount := gridSize * gridSize
+
+	if bombs > 0 {
+		if err := stakemine.ValidateBoard(gridSize, bombs); err != nil {
+			return nil, err
+		}
+		return []minesBoard{{name: "custom", tileCount: tileCount, bombCount: bombs}}, nil
+	}
+
+	boards := make([]minesBoard, 0, 3)
+	for _, risk := range []string{"low", "medium", "high"} {
+		bombCount := stakemine.GetBombCountForGrid(risk, tileCount)
+		if err := stakemine.ValidateBoard(gridSize, bombCount); err != nil {
+			return nil, err
+		}
+		boards = append(boards, minesBoard{name: risk, tileCount: tileCount, bombCount: bombCount})
+	}
+	return boards, nil
+}
+
+func simulateMines(boards []minesBoard, games int, bet float64, houseEdge float64, workers int) {
+	fmt.Printf("Stake mines: %d games per board, bet %.2f, house edge %.2f%%\n", games, bet, houseEdge*100)
+	fmt.Println("Strategy \"reveal N\" reveals N tiles and cashes out if no bomb was hit.")
+
+	for _, board := range boards {
+		maxDiamonds := stakemine.GetMaxDiamonds(board.tileCount, board.bombCount)
+
+		total := make([]stats, maxDiamonds+1)
+		runParallel(games, workers,
+			func() interface{} { return make([]stats, maxDiamonds+1) },
+			func(rounds int, result interface{}) {
+				r := result.([]stats)
+				for i := 0; i < rounds; i++ {
+					grid, err := stakemine.GenerateGrid(board.tileCount, board.bombCount)
+					if err != nil {
+						log.Fatalf("Error generating grid: %v", err)
+					}
+
+					// the grid is shuffled, so revealing tiles in index order is as good as any order
+					safeReveals := 0
+					for _, tile := range grid {
+						if tile.Type == "bomb" {
+							break
+						}
+						safeReveals++
+					}
+
+					for reveals := 1; reveals <= maxDiamonds; reveals++ {
+						if safeReveals < reveals {
+							r[reveals].add(0)
+							continue
+						}
+
+						multiplier := stakemine.CalculateMultiplier(reveals, board.tileCount, board.bombCount, houseEdge)
+						payout, err := stakemine.CalculatePayoutSafe(bet, multiplier)
+						if err != nil {
+							log.Fatalf("Error calculating payout: %v", err)
+						}
+						r[reveals].add(payout / bet)
+					}
+				}
+			},
+			func(result interface{}) {
+				r := result.([]stats)
+				for i := range total {
+					total[i].merge(&r[i])
+				}
+			},
+		)
+
+		fmt.Printf("\nBoard %q (%d tiles, %d bombs)\n", board.name, board.tileCount, board.bombCount)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "strategy\tmultiplier\tRTP\thit freq\tvariance\tstd dev\tmax win\t")
+		for reveals := 1; reveals <= maxDiamonds; reveals++ {
+			s := total[reveals]
+			fmt.Fprintf(w, "reveal %d\t%.2fx\t%.4f%%\t%.4f%%\t%.4f\t%.4f\t%.2fx\t\n",
+				reveals,
+				stakemine.CalculateMultiplier(reveals, board.tileCount, board.bombCount, houseEdge),
+				s.rtp()*100,
+				s.hitFrequency()*100,
+				s.variance(),
+				math.Sqrt(s.variance()),
+				s.max,
+			)
+		}
+		w.Flush()
+	}
+}
+
+func roundToTwoDecimals(value float64) float64 {
+	return float64(int(value*100+0.5)) / 100
+}
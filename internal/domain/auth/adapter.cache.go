@@ -20,6 +20,22 @@ func (r *authRepositoryImpl) GetCacheValue(key string, value interface{}) error
 	return r.cache.GetValue(key, value)
 }
 
+func (r *authRepositoryImpl) GetAndDeleteCacheValue(key string, value interface{}) error {
+	return r.cache.GetDelValue(key, value)
+}
+
 func (r *authRepositoryImpl) DeleteCacheValue(key string) error {
-	return nil
+	return r.cache.DeleteValue(key)
+}
+
+func (r *authRepositoryImpl) AddSetMember(key string, member string, ttl int) error {
+	return r.cache.AddSetMember(key, member, ttl)
+}
+
+func (r *authRepositoryImpl) GetSetMembers(key string) ([]string, error) {
+	return r.cache.GetSetMembers(key)
+}
+
+func (r *authRepositoryImpl) RemoveSetMember(key string, member string) error {
+	return r.cache.RemoveSetMember(key, member)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
 	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -26,6 +27,10 @@ func (h *AuthHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.Mi
 	router.Get("/callback", h.OAuthCallback)        // Google redirects
 	router.Post("/login/callback", h.LoginCallback) // Legacy: Frontend calls
 	router.Post("/refresh", h.RefreshToken)
+	router.Post("/logout", mid.AuthMiddleware, h.Logout)
+	router.Post("/logout-all", mid.AuthMiddleware, h.LogoutAll)
+	router.Get("/sessions", mid.AuthMiddleware, h.GetSessions)
+	router.Delete("/sessions/:id", mid.AuthMiddleware, h.RevokeSession)
 	router.Get("/me", mid.AuthMiddleware, h.GetMe)
 }
 
@@ -75,7 +80,7 @@ func (h *AuthHttpHandler) OAuthCallback(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "missing authorization code"})
 	}
 
-	credential, err := h.service.VerifyOAuthLogin(code)
+	credential, err := h.service.VerifyOAuthLogin(code, c.Get("User-Agent"), c.IP())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -128,7 +133,7 @@ func (h *AuthHttpHandler) LoginCallback(c *fiber.Ctx) error {
 
 	state := c.Query("state")
 
-	credential, err := h.service.VerifyOAuthLogin(oauthCodeDto.Code)
+	credential, err := h.service.VerifyOAuthLogin(oauthCodeDto.Code, c.Get("User-Agent"), c.IP())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -154,14 +159,14 @@ func (h *AuthHttpHandler) LoginCallback(c *fiber.Ctx) error {
 }
 
 // @Summary Refresh Token
-// @Description Refreshes the access token using the refresh token
+// @Description Exchanges a refresh token for a new access and refresh token. The old refresh token is invalidated and reusing it revokes every token rotated from the same login.
 // @Tags Auth
 // @Accept  json
 // @Produce  json
 // @Param   refreshToken  body      model.RefreshTokenDto  true  "Refresh Token"
 // @Success 200 {object} map[string]interface{} "credential"
 // @Failure 400 {object} map[string]string "cannot parse body"
-// @Failure 500 {object} map[string]string "internal server error"
+// @Failure 401 {object} map[string]string "invalid refresh token"
 // @Router  /auth/refresh [post]
 func (h *AuthHttpHandler) RefreshToken(c *fiber.Ctx) error {
 	var refreshTokenDto model.RefreshTokenDto
@@ -171,7 +176,7 @@ func (h *AuthHttpHandler) RefreshToken(c *fiber.Ctx) error {
 
 	credential, err := h.service.RefreshToken(refreshTokenDto.RefreshToken)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -179,6 +184,84 @@ func (h *AuthHttpHandler) RefreshToken(c *fiber.Ctx) error {
 	})
 }
 
+// @Summary Logout
+// @Description Revokes the session the access token belongs to
+// @Tags Auth
+// @Produce  json
+// @Success 204
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router  /auth/logout [post]
+// @Security BearerAuth
+func (h *AuthHttpHandler) Logout(c *fiber.Ctx) error {
+	userDto := utils.GetUserProfileFromCtx(c)
+
+	if err := h.service.Logout(userDto.Id, utils.GetSessionIdFromCtx(c)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to logout"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Logout all sessions
+// @Description Revokes every access and refresh token issued to the user
+// @Tags Auth
+// @Produce  json
+// @Success 204
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router  /auth/logout-all [post]
+// @Security BearerAuth
+func (h *AuthHttpHandler) LogoutAll(c *fiber.Ctx) error {
+	userDto := utils.GetUserProfileFromCtx(c)
+
+	if err := h.service.RevokeAllSessions(userDto.Id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to logout"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Get sessions
+// @Description Lists the devices the user is logged in on, most recently used first
+// @Tags Auth
+// @Produce  json
+// @Success 200 {array} model.SessionDto
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router  /auth/sessions [get]
+// @Security BearerAuth
+func (h *AuthHttpHandler) GetSessions(c *fiber.Ctx) error {
+	userDto := utils.GetUserProfileFromCtx(c)
+
+	sessions, err := h.service.GetSessions(userDto.Id, utils.GetSessionIdFromCtx(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get sessions"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(sessions)
+}
+
+// @Summary Revoke session
+// @Description Logs out one of the user's devices
+// @Tags Auth
+// @Produce  json
+// @Param   id  path  string  true  "Session ID"
+// @Success 204
+// @Failure 401 {object} map[string]string "unauthorized"
+// @Failure 404 {object} map[string]string "session not found"
+// @Router  /auth/sessions/{id} [delete]
+// @Security BearerAuth
+func (h *AuthHttpHandler) RevokeSession(c *fiber.Ctx) error {
+	userDto := utils.GetUserProfileFromCtx(c)
+
+	if err := h.service.RevokeSession(userDto.Id, c.Params("id")); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // @Summary GetMe
 // @Description Retrieves user profile data
 // @Tags Auth
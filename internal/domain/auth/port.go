@@ -4,13 +4,28 @@ import "github.com/esc-chula/intania-888-backend/internal/model"
 
 type AuthService interface {
 	GetOAuthUrl(redirectTo string) (string, error)
-	VerifyOAuthLogin(code string) (*model.CredentialDto, error)
+	VerifyOAuthLogin(code string, userAgent string, ipAddress string) (*model.CredentialDto, error)
 	RefreshToken(refreshToken string) (*model.CredentialDto, error)
+	Logout(userId string, sessionId string) error
+	GetSessions(userId string, currentSessionId string) ([]model.SessionDto, error)
+	RevokeSession(userId string, sessionId string) error
+	RevokeAllSessions(userId string) error
 	IsAllowedRedirect(redirectUrl string) bool
 	GetFrontendUrl() string
 }
 
+// BanChecker reports whether a user is banned from logging in, or an account from signing up
+type BanChecker interface {
+	IsBanned(userId string) (bool, error)
+	IsSignupBanned(userId string, email string) (bool, error)
+}
+
 type AuthRepository interface {
 	SetCacheValue(key string, value interface{}, ttl int) error
 	GetCacheValue(key string, value interface{}) error
+	GetAndDeleteCacheValue(key string, value interface{}) error
+	DeleteCacheValue(key string) error
+	AddSetMember(key string, member string, ttl int) error
+	GetSetMembers(key string) ([]string, error)
+	RemoveSetMember(key string, member string) error
 }
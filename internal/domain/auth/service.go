@@ -1,14 +1,18 @@
 package auth
 
 import (
+	"errors"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/esc-chula/intania-888-backend/internal/domain/user"
 	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/esc-chula/intania-888-backend/pkg/config"
 	"github.com/esc-chula/intania-888-backend/pkg/oauth"
 	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -16,15 +20,17 @@ import (
 type authServiceImpl struct {
 	authRepo    AuthRepository
 	userRepo    user.UserRepository
+	banChecker  BanChecker
 	cfg         config.Config
 	log         *zap.Logger
 	oauthClient oauth.GoogleOAuthClient
 }
 
-func NewAuthService(authRepo AuthRepository, userRepo user.UserRepository, cfg config.Config, log *zap.Logger, oauthClient oauth.GoogleOAuthClient) AuthService {
+func NewAuthService(authRepo AuthRepository, userRepo user.UserRepository, banChecker BanChecker, cfg config.Config, log *zap.Logger, oauthClient oauth.GoogleOAuthClient) AuthService {
 	return &authServiceImpl{
 		authRepo:    authRepo,
 		userRepo:    userRepo,
+		banChecker:  banChecker,
 		cfg:         cfg,
 		log:         log,
 		oauthClient: oauthClient,
@@ -55,7 +61,7 @@ func (s *authServiceImpl) GetOAuthUrl(redirectTo string) (string, error) {
 	return urlString, nil
 }
 
-func (s *authServiceImpl) VerifyOAuthLogin(code string) (*model.CredentialDto, error) {
+func (s *authServiceImpl) VerifyOAuthLogin(code string, userAgent string, ipAddress string) (*model.CredentialDto, error) {
 	userInfo, err := s.oauthClient.GetUserInfo(code)
 	if err != nil {
 		s.log.Named("VerifyOAuthLogin").Error("Get user info: ", zap.Error(err))
@@ -85,35 +91,35 @@ func (s *authServiceImpl) VerifyOAuthLogin(code string) (*model.CredentialDto, e
 		return nil, gorm.ErrInvalidData
 	}
 
-	blacklistedEmails := []string{
-		"6530162621@student.chula.ac.th",
-		"6633129621@student.chula.ac.th",
-		"6733023821@student.chula.ac.th",
-		"6630054621@student.chula.ac.th",
-		"6538004621@student.chula.ac.th",
-		"6733291621@student.chula.ac.th",
-		"6430039021@student.chula.ac.th",
-	}
-
-	for _, email := range blacklistedEmails {
-		if userInfo.Email == email {
-			s.log.Named("VerifyOAuthLogin").Warn("Idiot",
-				zap.String("email", userInfo.Email))
+	existedUser, err := s.userRepo.GetByEmail(userInfo.Email)
+	if err != nil && err == gorm.ErrRecordNotFound {
+		if banned, err := s.banChecker.IsSignupBanned(userInfo.Id, userInfo.Email); err != nil || banned {
+			s.log.Named("VerifyOAuthLogin").Warn("Banned account attempted sign up",
+				zap.String("email", userInfo.Email), zap.Error(err))
 			return nil, gorm.ErrInvalidData
 		}
-	}
 
-	existedUser, err := s.userRepo.GetByEmail(userInfo.Email)
-	if err != nil && err == gorm.ErrRecordNotFound {
 		s.log.Named("VerifyOAuthLogin").Info("User not found, creating new user")
 
-		role := "USER"
+		// Users joining mid-season start with the same balance everyone was reset to
+		startingCoins, err := s.userRepo.GetStartingCoins()
+		if err != nil {
+			s.log.Named("VerifyOAuthLogin").Error("Get starting coins: ", zap.Error(err))
+			return nil, err
+		}
+
+		role, err := s.userRepo.GetSignupRole(userInfo.Email)
+		if err != nil {
+			s.log.Named("VerifyOAuthLogin").Error("Get signup role: ", zap.Error(err))
+			return nil, err
+		}
+
 		userToCreate := model.User{
 			Id:            userInfo.Id,
 			Email:         userInfo.Email,
 			Name:          userInfo.Name,
 			RoleId:        role,
-			RemainingCoin: 888.88,
+			RemainingCoin: startingCoins,
 		}
 
 		if err := s.userRepo.Create(&userToCreate); err != nil {
@@ -121,85 +127,232 @@ func (s *authServiceImpl) VerifyOAuthLogin(code string) (*model.CredentialDto, e
 			return nil, err
 		}
 
-		accessToken, err := utils.JwtSignAccessToken(userInfo.Id, role, s.cfg.GetJwt().AccessTokenSecret, s.cfg.GetJwt().AccessTokenExpiration)
-		if err != nil {
-			s.log.Named("VerifyOAuthLogin").Error("Jwt sign access token: ", zap.Error(err))
-			return nil, err
-		}
-
-		refreshToken, err := utils.JwtSignRefreshToken(s.cfg.GetJwt().RefreshTokenExpiration)
-		if err != nil {
-			s.log.Named("VerifyOAuthLogin").Error("Jwt sign refresh token: ", zap.Error(err))
-			return nil, err
-		}
-
-		credential := utils.NewCredentials(*accessToken, *refreshToken, int32(s.cfg.GetJwt().AccessTokenExpiration), true)
+		return s.createSession(userToCreate.Id, role, userAgent, ipAddress, true)
+	}
 
-		if err := s.authRepo.SetCacheValue(utils.ToAccessCacheKey(userToCreate.Id), credential, s.cfg.GetJwt().AccessTokenExpiration); err != nil {
-			s.log.Named("VerifyOAuthLogin").Error("Set access cache value: ", zap.Error(err))
-			return nil, err
-		}
+	if banned, err := s.banChecker.IsBanned(existedUser.Id); err != nil || banned {
+		s.log.Named("VerifyOAuthLogin").Warn("Banned user attempted login",
+			zap.String("email", userInfo.Email), zap.Error(err))
+		return nil, gorm.ErrInvalidData
+	}
 
-		if err := s.authRepo.SetCacheValue(utils.ToRefreshCacheKey(*refreshToken), model.RefreshCacheDto{UserId: userToCreate.Id, Role: role}, s.cfg.GetJwt().RefreshTokenExpiration); err != nil {
-			s.log.Named("VerifyOAuthLogin").Error("Set access cache value: ", zap.Error(err))
-			return nil, err
-		}
+	return s.createSession(existedUser.Id, existedUser.RoleId, userAgent, ipAddress, false)
+}
 
-		return credential, nil
+// createSession starts a new session for a fresh login, refresh tokens rotated from it stay in the session
+func (s *authServiceImpl) createSession(userId string, role string, userAgent string, ipAddress string, isNewUser bool) (*model.CredentialDto, error) {
+	now := time.Now()
+	session := &model.SessionCacheDto{
+		Id:         uuid.NewString(),
+		UserId:     userId,
+		UserAgent:  userAgent,
+		IpAddress:  ipAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
 	}
+	return s.issueCredential(session, role, isNewUser)
+}
 
-	accessToken, err := utils.JwtSignAccessToken(existedUser.Id, existedUser.RoleId, s.cfg.GetJwt().AccessTokenSecret, s.cfg.GetJwt().AccessTokenExpiration)
+// issueCredential signs a token pair for the session, stores the refresh token as the session's latest
+// and indexes the session under the user
+func (s *authServiceImpl) issueCredential(session *model.SessionCacheDto, role string, isNewUser bool) (*model.CredentialDto, error) {
+	accessToken, err := utils.JwtSignAccessToken(session.UserId, session.Id, role, s.cfg.GetJwt().AccessTokenSecret, s.cfg.GetJwt().AccessTokenExpiration)
 	if err != nil {
-		s.log.Named("VerifyOAuthLogin").Error("Jwt sign access token: ", zap.Error(err))
+		s.log.Named("issueCredential").Error("Jwt sign access token: ", zap.Error(err))
 		return nil, err
 	}
 
 	refreshToken, err := utils.JwtSignRefreshToken(s.cfg.GetJwt().RefreshTokenExpiration)
 	if err != nil {
-		s.log.Named("VerifyOAuthLogin").Error("Jwt sign refresh token: ", zap.Error(err))
+		s.log.Named("issueCredential").Error("Jwt sign refresh token: ", zap.Error(err))
 		return nil, err
 	}
 
-	credential := utils.NewCredentials(*accessToken, *refreshToken, int32(s.cfg.GetJwt().AccessTokenExpiration), false)
+	credential := utils.NewCredentials(*accessToken, *refreshToken, int32(s.cfg.GetJwt().AccessTokenExpiration), isNewUser)
+	refreshTtl := s.cfg.GetJwt().RefreshTokenExpiration
 
-	if err := s.authRepo.SetCacheValue(utils.ToAccessCacheKey(existedUser.Id), credential, s.cfg.GetJwt().AccessTokenExpiration); err != nil {
-		s.log.Named("VerifyOAuthLogin").Error("Set access cache value: ", zap.Error(err))
+	if err := s.authRepo.SetCacheValue(utils.ToAccessCacheKey(session.UserId, session.Id), credential, s.cfg.GetJwt().AccessTokenExpiration); err != nil {
+		s.log.Named("issueCredential").Error("Set access cache value: ", zap.Error(err))
 		return nil, err
 	}
 
-	if err := s.authRepo.SetCacheValue(utils.ToRefreshCacheKey(*refreshToken), model.RefreshCacheDto{UserId: existedUser.Id, Role: existedUser.RoleId}, s.cfg.GetJwt().RefreshTokenExpiration); err != nil {
-		s.log.Named("VerifyOAuthLogin").Error("Set access cache value: ", zap.Error(err))
+	refreshCacheDto := model.RefreshCacheDto{UserId: session.UserId, Role: role, SessionId: session.Id}
+	if err := s.authRepo.SetCacheValue(utils.ToRefreshCacheKey(*refreshToken), refreshCacheDto, refreshTtl); err != nil {
+		s.log.Named("issueCredential").Error("Set refresh cache value: ", zap.Error(err))
+		return nil, err
+	}
+
+	session.RefreshToken = *refreshToken
+	if err := s.authRepo.SetCacheValue(utils.ToSessionCacheKey(session.Id), session, refreshTtl); err != nil {
+		s.log.Named("issueCredential").Error("Set session cache value: ", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.authRepo.AddSetMember(utils.ToSessionIndexCacheKey(session.UserId), session.Id, refreshTtl); err != nil {
+		s.log.Named("issueCredential").Error("Add session index: ", zap.Error(err))
 		return nil, err
 	}
 
 	return credential, nil
 }
 
+// RefreshToken exchanges a refresh token for a new token pair. Each refresh token can be used once,
+// presenting one that was already exchanged revokes its whole session.
 func (s *authServiceImpl) RefreshToken(refreshToken string) (*model.CredentialDto, error) {
-	// find in cache
+	// Consume the token atomically so concurrent refreshes cannot both succeed
 	var refreshCacheDto model.RefreshCacheDto
-	var emptyCache model.RefreshCacheDto
-	if err := s.authRepo.GetCacheValue(utils.ToRefreshCacheKey(refreshToken), &refreshCacheDto); err != nil {
-		s.log.Named("RefreshToken").Error("Get cache value: ", zap.Error(err))
-		return nil, err
-	} else if refreshCacheDto == emptyCache {
-		s.log.Named("RefreshToken").Info("Get cache value: refresh token not found")
+	if err := s.authRepo.GetAndDeleteCacheValue(utils.ToRefreshCacheKey(refreshToken), &refreshCacheDto); err != nil {
+		var rotated model.RefreshCacheDto
+		if rotatedErr := s.authRepo.GetCacheValue(utils.ToRotatedRefreshCacheKey(refreshToken), &rotated); rotatedErr == nil {
+			s.log.Named("RefreshToken").Warn("Rotated refresh token reused, revoking session",
+				zap.String("user_id", rotated.UserId),
+				zap.String("session_id", rotated.SessionId))
+			if revokeErr := s.revokeSession(rotated.UserId, rotated.SessionId); revokeErr != nil {
+				s.log.Named("RefreshToken").Error("Revoke session: ", zap.Error(revokeErr))
+			}
+		} else {
+			s.log.Named("RefreshToken").Info("Get cache value: refresh token not found")
+		}
+		return nil, errors.New("invalid refresh token")
+	}
+
+	refreshTtl := s.cfg.GetJwt().RefreshTokenExpiration
+	if err := s.authRepo.SetCacheValue(utils.ToRotatedRefreshCacheKey(refreshToken), refreshCacheDto, refreshTtl); err != nil {
+		s.log.Named("RefreshToken").Error("Set rotated cache value: ", zap.Error(err))
 		return nil, err
 	}
 
-	accessToken, err := utils.JwtSignAccessToken(refreshCacheDto.UserId, refreshCacheDto.Role, s.cfg.GetJwt().AccessTokenSecret, s.cfg.GetJwt().AccessTokenExpiration)
+	var session model.SessionCacheDto
+	if err := s.authRepo.GetCacheValue(utils.ToSessionCacheKey(refreshCacheDto.SessionId), &session); err != nil {
+		s.log.Named("RefreshToken").Info("Session not found", zap.String("session_id", refreshCacheDto.SessionId))
+		return nil, errors.New("invalid refresh token")
+	}
+
+	// Role and ban state may have changed since the token was issued
+	user, err := s.userRepo.GetById(refreshCacheDto.UserId)
 	if err != nil {
-		s.log.Named("RefreshToken").Error("Jwt sign access token: ", zap.Error(err))
-		return nil, err
+		s.log.Named("RefreshToken").Error("Get user by id: ", zap.Error(err))
+		return nil, errors.New("invalid refresh token")
+	}
+	if banned, err := s.banChecker.IsBanned(user.Id); err != nil || banned {
+		s.log.Named("RefreshToken").Warn("Banned user attempted refresh", zap.String("user_id", user.Id), zap.Error(err))
+		if err := s.RevokeAllSessions(user.Id); err != nil {
+			s.log.Named("RefreshToken").Error("Revoke all sessions: ", zap.Error(err))
+		}
+		return nil, errors.New("invalid refresh token")
 	}
 
-	newCredential := utils.NewCredentials(*accessToken, refreshToken, int32(s.cfg.GetJwt().AccessTokenExpiration), false)
-	if err := s.authRepo.SetCacheValue(utils.ToAccessCacheKey(refreshCacheDto.UserId), newCredential, s.cfg.GetJwt().AccessTokenExpiration); err != nil {
-		s.log.Named("RefreshToken").Error("Set access cache value: ", zap.Error(err))
+	session.LastSeenAt = time.Now()
+	return s.issueCredential(&session, user.RoleId, false)
+}
+
+// revokeSession deletes the session with its latest refresh token and access token
+func (s *authServiceImpl) revokeSession(userId string, sessionId string) error {
+	var session model.SessionCacheDto
+	if err := s.authRepo.GetAndDeleteCacheValue(utils.ToSessionCacheKey(sessionId), &session); err == nil {
+		if err := s.authRepo.DeleteCacheValue(utils.ToRefreshCacheKey(session.RefreshToken)); err != nil {
+			return err
+		}
+	}
+
+	if err := s.authRepo.DeleteCacheValue(utils.ToAccessCacheKey(userId, sessionId)); err != nil {
+		return err
+	}
+
+	return s.authRepo.RemoveSetMember(utils.ToSessionIndexCacheKey(userId), sessionId)
+}
+
+// Logout revokes the session the caller is using
+func (s *authServiceImpl) Logout(userId string, sessionId string) error {
+	if err := s.revokeSession(userId, sessionId); err != nil {
+		s.log.Named("Logout").Error("Revoke session: ", zap.Error(err))
+		return err
+	}
+
+	s.log.Named("Logout").Info("Success: ", zap.String("user_id", userId), zap.String("session_id", sessionId))
+	return nil
+}
+
+// GetSessions lists the user's live sessions, most recently used first
+func (s *authServiceImpl) GetSessions(userId string, currentSessionId string) ([]model.SessionDto, error) {
+	indexKey := utils.ToSessionIndexCacheKey(userId)
+
+	sessionIds, err := s.authRepo.GetSetMembers(indexKey)
+	if err != nil {
+		s.log.Named("GetSessions").Error("Get session index: ", zap.Error(err))
 		return nil, err
 	}
 
-	return newCredential, nil
+	sessions := make([]model.SessionDto, 0, len(sessionIds))
+	for _, sessionId := range sessionIds {
+		var session model.SessionCacheDto
+		if err := s.authRepo.GetCacheValue(utils.ToSessionCacheKey(sessionId), &session); err != nil {
+			// The session expired with its refresh token, drop it from the index
+			if err := s.authRepo.RemoveSetMember(indexKey, sessionId); err != nil {
+				s.log.Named("GetSessions").Warn("Remove session index: ", zap.Error(err))
+			}
+			continue
+		}
+
+		sessions = append(sessions, model.SessionDto{
+			Id:         session.Id,
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IpAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			IsCurrent:  session.Id == currentSessionId,
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession revokes one of the user's own sessions
+func (s *authServiceImpl) RevokeSession(userId string, sessionId string) error {
+	var session model.SessionCacheDto
+	if err := s.authRepo.GetCacheValue(utils.ToSessionCacheKey(sessionId), &session); err != nil || session.UserId != userId {
+		return errors.New("session not found")
+	}
+
+	if err := s.revokeSession(userId, sessionId); err != nil {
+		s.log.Named("RevokeSession").Error("Revoke session: ", zap.Error(err))
+		return err
+	}
+
+	s.log.Named("RevokeSession").Info("Success: ", zap.String("user_id", userId), zap.String("session_id", sessionId))
+	return nil
+}
+
+// RevokeAllSessions deletes every access and refresh token issued to the user
+func (s *authServiceImpl) RevokeAllSessions(userId string) error {
+	indexKey := utils.ToSessionIndexCacheKey(userId)
+
+	sessionIds, err := s.authRepo.GetSetMembers(indexKey)
+	if err != nil {
+		s.log.Named("RevokeAllSessions").Error("Get session index: ", zap.Error(err))
+		return err
+	}
+
+	for _, sessionId := range sessionIds {
+		if err := s.revokeSession(userId, sessionId); err != nil {
+			s.log.Named("RevokeAllSessions").Error("Revoke session: ", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := s.authRepo.DeleteCacheValue(indexKey); err != nil {
+		s.log.Named("RevokeAllSessions").Error("Delete session index: ", zap.Error(err))
+		return err
+	}
+
+	s.log.Named("RevokeAllSessions").Info("Success: ",
+		zap.String("user_id", userId),
+		zap.Int("revoked_sessions", len(sessionIds)))
+	return nil
 }
 
 func (s *authServiceImpl) IsAllowedRedirect(redirectUrl string) bool {
@@ -32,7 +32,10 @@ func (r *billRepositoryImpl) GetById(billId, userId string) (*model.BillHead, er
 // GetAll retrieves all bills for a specific user
 func (r *billRepositoryImpl) GetAll(userId string) ([]*model.BillHead, error) {
 	var bills []*model.BillHead
-	err := r.db.Preload("Lines").Preload("Lines.Match").Where("user_id = ?", userId).Find(&bills).Error
+	err := r.db.Preload("Lines").Preload("Lines.Match").
+		Where("user_id = ?", userId).
+		Where(model.InActiveSeason("season_id")).
+		Find(&bills).Error
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +26,8 @@ func (h *BillHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.Mi
 	router.Patch("/:id", h.UpdateBill)
 	router.Delete("/:id", h.DeleteBill)
 
-	adminRouter := router.Group("/admin", mid.AdminMiddleware)
-	adminRouter.Get("/all", h.GetAllBillsAdmin)
+	adminRouter := router.Group("/admin")
+	adminRouter.Get("/all", mid.RequirePermission(model.PermBillReadAll), h.GetAllBillsAdmin)
 }
 
 // CreateBill godoc
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/esc-chula/intania-888-backend/internal/domain/quest"
 	"github.com/esc-chula/intania-888-backend/internal/domain/user"
 	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/google/uuid"
@@ -13,19 +14,22 @@ import (
 )
 
 type billServiceImpl struct {
-	repo     BillRepository
-	userRepo user.UserRepository
-	db       *gorm.DB
-	log      *zap.Logger
+	repo         BillRepository
+	userRepo     user.UserRepository
+	questTracker quest.QuestTracker
+	db           *gorm.DB
+	log          *zap.Logger
 }
 
 // Create a new instance of BillService
-func NewBillService(repo BillRepository, userRepo user.UserRepository, db *gorm.DB, log *zap.Logger) BillService {
-	return &billServiceImpl{repo, userRepo, db, log}
+func NewBillService(repo BillRepository, userRepo user.UserRepository, questTracker quest.QuestTracker, db *gorm.DB, log *zap.Logger) BillService {
+	return &billServiceImpl{repo, userRepo, questTracker, db, log}
 }
 
 func (s *billServiceImpl) CreateBill(userProfile *model.UserDto, billDto *model.BillHeadDto) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	var sportTypeIds []string
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
 		var user model.User
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("id = ?", userProfile.Id).
@@ -42,8 +46,11 @@ func (s *billServiceImpl) CreateBill(userProfile *model.UserDto, billDto *model.
 
 		currentTime := time.Now()
 		for _, lineDto := range billDto.Lines {
+			// Matches of archived seasons are hidden from listings and cannot be bet on either
 			var match model.Match
-			if err := tx.Where("id = ?", lineDto.MatchId).First(&match).Error; err != nil {
+			if err := tx.Where("id = ?", lineDto.MatchId).
+				Where(model.InActiveSeason("season_id")).
+				First(&match).Error; err != nil {
 				s.log.Named("CreateBill").Error("Failed to fetch match", zap.String("match_id", lineDto.MatchId), zap.Error(err))
 				return errors.New("match not found: " + lineDto.MatchId)
 			}
@@ -56,6 +63,8 @@ func (s *billServiceImpl) CreateBill(userProfile *model.UserDto, billDto *model.
 					zap.Time("current_time", currentTime))
 				return err
 			}
+
+			sportTypeIds = append(sportTypeIds, match.TypeId)
 		}
 
 		bill := mapBillDtoToEntity(billDto)
@@ -80,6 +89,16 @@ func (s *billServiceImpl) CreateBill(userProfile *model.UserDto, billDto *model.
 		s.log.Named("CreateBill").Info("Created bill successful", zap.Any("bill", bill))
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.questTracker.Track(userProfile.Id, &quest.Event{
+		Type:         quest.EventBillPlaced,
+		Lines:        len(billDto.Lines),
+		SportTypeIds: sportTypeIds,
+	})
+	return nil
 }
 
 // GetBill returns a bill by id
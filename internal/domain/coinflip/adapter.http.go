@@ -0,0 +1,174 @@
+package coinflip
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CoinflipHttpHandler struct {
+	service CoinflipService
+}
+
+func NewCoinflipHttpHandler(service CoinflipService) *CoinflipHttpHandler {
+	return &CoinflipHttpHandler{service: service}
+}
+
+func (h *CoinflipHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/coinflip", mid.AuthMiddleware)
+
+	router.Post("/create", h.CreateGame)
+	router.Get("/history", h.GetHistory)
+	router.Get("/stats", h.GetStats)
+	router.Get("/seed", h.GetSeed)
+	router.Post("/seed/rotate", h.RotateSeed)
+}
+
+// @Summary Play a coin-flip game
+// @Description Bet on heads or tails, settled instantly
+// @Tags Coinflip
+// @Accept json
+// @Produce json
+// @Param request body model.CreateCoinflipRequest true "Game creation request"
+// @Success 200 {object} model.GameRoundDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /coinflip/create [post]
+// @Security BearerAuth
+func (h *CoinflipHttpHandler) CreateGame(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.CreateCoinflipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	game, err := h.service.CreateGame(profile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
+
+// @Summary Get game history
+// @Description Get the user's coin-flip games, newest first
+// @Tags Coinflip
+// @Produce json
+// @Param limit query int false "Number of games to return (default 20, max 100)"
+// @Param offset query int false "Number of games to skip"
+// @Success 200 {array} model.GameRoundDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /coinflip/history [get]
+// @Security BearerAuth
+func (h *CoinflipHttpHandler) GetHistory(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	history, err := h.service.GetGameHistory(profile.Id, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get game history",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":   history,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// @Summary Get user statistics
+// @Description Get statistics for the user's coin-flip games
+// @Tags Coinflip
+// @Produce json
+// @Success 200 {object} model.GameStatsDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /coinflip/stats [get]
+// @Security BearerAuth
+func (h *CoinflipHttpHandler) GetStats(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	stats, err := h.service.GetStats(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get statistics",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+// @Summary Get server seed commitment
+// @Description Get the hashed server seed, client seed and next nonce used for the user's coin-flip games
+// @Tags Coinflip
+// @Produce json
+// @Success 200 {object} model.GameSeedDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /coinflip/seed [get]
+// @Security BearerAuth
+func (h *CoinflipHttpHandler) GetSeed(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	seed, err := h.service.GetSeed(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get server seed",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seed)
+}
+
+// @Summary Rotate server seed
+// @Description Reveal the current server seed and commit a new one with an optional client seed
+// @Tags Coinflip
+// @Accept json
+// @Produce json
+// @Param request body model.RotateGameSeedRequest false "New client seed"
+// @Success 200 {object} model.RotateGameSeedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /coinflip/seed/rotate [post]
+// @Security BearerAuth
+func (h *CoinflipHttpHandler) RotateSeed(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.RotateGameSeedRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "cannot parse body",
+			})
+		}
+	}
+
+	res, err := h.service.RotateSeed(profile.Id, req.ClientSeed)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
@@ -0,0 +1,13 @@
+package coinflip
+
+import (
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+type CoinflipService interface {
+	CreateGame(userId string, req *model.CreateCoinflipRequest) (*model.GameRoundDto, error)
+	GetGameHistory(userId string, limit int, offset int) ([]model.GameRoundDto, error)
+	GetStats(userId string) (*model.GameStatsDto, error)
+	GetSeed(userId string) (*model.GameSeedDto, error)
+	RotateSeed(userId string, clientSeed string) (*model.RotateGameSeedResponse, error)
+}
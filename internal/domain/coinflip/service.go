@@ -0,0 +1,57 @@
+package coinflip
+
+import (
+	"errors"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"go.uber.org/zap"
+)
+
+type coinflipServiceImpl struct {
+	gameSvc stakegame.GameService
+	cfg     config.Config
+	log     *zap.Logger
+}
+
+func NewCoinflipService(gameSvc stakegame.GameService, cfg config.Config, log *zap.Logger) CoinflipService {
+	return &coinflipServiceImpl{
+		gameSvc: gameSvc,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+func (s *coinflipServiceImpl) CreateGame(userId string, req *model.CreateCoinflipRequest) (*model.GameRoundDto, error) {
+	if !ValidateSide(req.Side) {
+		s.log.Named("CreateGame").Error("Invalid side", zap.String("side", req.Side))
+		return nil, errors.New("invalid side. must be 'heads' or 'tails'")
+	}
+
+	multiplier := CalculateMultiplier(s.cfg.GetGame().CoinflipHouseEdge)
+
+	return s.gameSvc.Play(&stakegame.Wager{
+		UserId:     userId,
+		Game:       stakegame.GameCoinflip,
+		BetAmount:  req.BetAmount,
+		Choice:     req.Side,
+		ClientSeed: req.ClientSeed,
+	}, Resolve(req.Side, multiplier))
+}
+
+func (s *coinflipServiceImpl) GetGameHistory(userId string, limit int, offset int) ([]model.GameRoundDto, error) {
+	return s.gameSvc.GetHistory(userId, stakegame.GameCoinflip, limit, offset)
+}
+
+func (s *coinflipServiceImpl) GetStats(userId string) (*model.GameStatsDto, error) {
+	return s.gameSvc.GetStats(userId, stakegame.GameCoinflip)
+}
+
+func (s *coinflipServiceImpl) GetSeed(userId string) (*model.GameSeedDto, error) {
+	return s.gameSvc.GetSeed(userId, stakegame.GameCoinflip)
+}
+
+func (s *coinflipServiceImpl) RotateSeed(userId string, clientSeed string) (*model.RotateGameSeedResponse, error) {
+	return s.gameSvc.RotateSeed(userId, stakegame.GameCoinflip, clientSeed)
+}
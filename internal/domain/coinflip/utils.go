@@ -0,0 +1,39 @@
+package coinflip
+
+import (
+	"math"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+)
+
+// Coin sides
+const (
+	SideHeads = "heads"
+	SideTails = "tails"
+)
+
+// ValidateSide checks if the side is valid
+func ValidateSide(side string) bool {
+	return side == SideHeads || side == SideTails
+}
+
+// CalculateMultiplier returns the payout of an even-money flip after the house edge
+func CalculateMultiplier(houseEdge float64) float64 {
+	return math.Floor(2*(1-houseEdge)*10000) / 10000
+}
+
+// Resolve flips heads for rolls below one half and tails otherwise
+func Resolve(side string, multiplier float64) stakegame.Resolver {
+	return func(roll float64) stakegame.Outcome {
+		result := SideTails
+		if roll < 0.5 {
+			result = SideHeads
+		}
+
+		return stakegame.Outcome{
+			Won:        result == side,
+			Multiplier: multiplier,
+			Result:     result,
+		}
+	}
+}
@@ -33,7 +33,7 @@ func (r *colorRepository) GetAllLeaderboards(typeId string) ([]*model.Color, err
 		LEFT JOIN matches 
 		ON (matches.winner_id IS NOT NULL OR matches.is_draw = TRUE) 
 		AND (colors.id = matches.teama_id OR colors.id = matches.teamb_id)
-	`
+		AND ` + model.InActiveSeason("matches.season_id")
 	if typeId != "" {
 		matchJoin += " AND matches.type_id = ?"
 		query = query.Joins(matchJoin, typeId)
@@ -67,7 +67,7 @@ func (r *colorRepository) GetGroupStageTable(typeId, groupId string) ([]*model.C
 		LEFT JOIN matches 
 		ON (matches.winner_id IS NOT NULL OR matches.is_draw = TRUE) 
 		AND (colors.id = matches.teama_id OR colors.id = matches.teamb_id)
-	`
+		AND ` + model.InActiveSeason("matches.season_id")
 	if typeId != "" {
 		matchJoin += " AND matches.type_id = ?"
 		query = query.Joins(matchJoin, typeId)
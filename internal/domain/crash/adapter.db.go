@@ -0,0 +1,86 @@
+package crash
+
+import (
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type crashRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCrashRepository(db *gorm.DB) CrashRepository {
+	return &crashRepositoryImpl{db: db}
+}
+
+func (r *crashRepositoryImpl) CreateRound(round *model.CrashRound) error {
+	return r.db.Create(round).Error
+}
+
+func (r *crashRepositoryImpl) FindRoundById(roundId string) (*model.CrashRound, error) {
+	var round model.CrashRound
+	err := r.db.Where("id = ?", roundId).First(&round).Error
+	if err != nil {
+		return nil, err
+	}
+	return &round, nil
+}
+
+func (r *crashRepositoryImpl) FindLatestRound() (*model.CrashRound, error) {
+	var round model.CrashRound
+	err := r.db.Where("status <> ?", StatusCancelled).
+		Order("created_at DESC").
+		First(&round).Error
+	if err != nil {
+		return nil, err
+	}
+	return &round, nil
+}
+
+func (r *crashRepositoryImpl) FindUnfinishedRoundIds() ([]string, error) {
+	var roundIds []string
+	err := r.db.Model(&model.CrashRound{}).
+		Where("status IN ?", []string{StatusBetting, StatusRunning}).
+		Pluck("id", &roundIds).Error
+	if err != nil {
+		return nil, err
+	}
+	return roundIds, nil
+}
+
+func (r *crashRepositoryImpl) FindCrashedRounds(limit int) ([]model.CrashRound, error) {
+	var rounds []model.CrashRound
+	err := r.db.Where("status = ?", StatusCrashed).
+		Order("crashed_at DESC").
+		Limit(limit).
+		Find(&rounds).Error
+	if err != nil {
+		return nil, err
+	}
+	return rounds, nil
+}
+
+func (r *crashRepositoryImpl) FindBetsByRoundId(roundId string) ([]model.CrashBet, error) {
+	var bets []model.CrashBet
+	err := r.db.Preload("User").
+		Where("round_id = ?", roundId).
+		Order("created_at ASC").
+		Find(&bets).Error
+	if err != nil {
+		return nil, err
+	}
+	return bets, nil
+}
+
+func (r *crashRepositoryImpl) FindBetsByUserId(userId string, limit int, offset int) ([]model.CrashBet, error) {
+	var bets []model.CrashBet
+	err := r.db.Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&bets).Error
+	if err != nil {
+		return nil, err
+	}
+	return bets, nil
+}
@@ -0,0 +1,190 @@
+package crash
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CrashHttpHandler struct {
+	service CrashService
+}
+
+func NewCrashHttpHandler(service CrashService) *CrashHttpHandler {
+	return &CrashHttpHandler{service: service}
+}
+
+func (h *CrashHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/crash", mid.AuthMiddleware)
+
+	router.Get("/current", h.GetCurrentRound)
+	router.Post("/bet", h.PlaceBet)
+	router.Post("/cashout", h.CashOut)
+	router.Get("/history", h.GetRoundHistory)
+	router.Get("/bets", h.GetBetHistory)
+	router.Get("/rounds/:id/verify", h.VerifyRound)
+}
+
+// @Summary Get the current crash round
+// @Description Get the current round with its live multiplier and the players who joined it. Clients animate the multiplier from started_at and server_time.
+// @Tags Crash
+// @Produce json
+// @Success 200 {object} model.CrashRoundDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /crash/current [get]
+// @Security BearerAuth
+func (h *CrashHttpHandler) GetCurrentRound(c *fiber.Ctx) error {
+	round, err := h.service.GetCurrentRound()
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(round)
+}
+
+// @Summary Join the current crash round
+// @Description Place a bet on the round in its betting window, with an optional auto cash-out multiplier
+// @Tags Crash
+// @Accept json
+// @Produce json
+// @Param request body model.PlaceCrashBetRequest true "Bet request"
+// @Success 200 {object} model.CrashBetDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /crash/bet [post]
+// @Security BearerAuth
+func (h *CrashHttpHandler) PlaceBet(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.PlaceCrashBetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	bet, err := h.service.PlaceBet(profile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(bet)
+}
+
+// @Summary Cash out of the running crash round
+// @Description Cash out the player's bet at the current multiplier before the round crashes
+// @Tags Crash
+// @Produce json
+// @Success 200 {object} model.CrashBetDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /crash/cashout [post]
+// @Security BearerAuth
+func (h *CrashHttpHandler) CashOut(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	bet, err := h.service.CashOut(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Successfully cashed out!",
+		"bet":     bet,
+	})
+}
+
+// @Summary Get recent crash rounds
+// @Description Get the most recent crashed rounds with their crash points and revealed seeds
+// @Tags Crash
+// @Produce json
+// @Param limit query int false "Number of rounds to return (default 20, max 100)"
+// @Success 200 {array} model.CrashRoundDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /crash/history [get]
+// @Security BearerAuth
+func (h *CrashHttpHandler) GetRoundHistory(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	rounds, err := h.service.GetRoundHistory(limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get round history",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":  rounds,
+		"limit": limit,
+	})
+}
+
+// @Summary Get the player's crash bets
+// @Description Get the player's crash bets, newest first
+// @Tags Crash
+// @Produce json
+// @Param limit query int false "Number of bets to return (default 20, max 100)"
+// @Param offset query int false "Number of bets to skip"
+// @Success 200 {array} model.CrashBetDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /crash/bets [get]
+// @Security BearerAuth
+func (h *CrashHttpHandler) GetBetHistory(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	bets, err := h.service.GetBetHistory(profile.Id, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get bet history",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":   bets,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// @Summary Verify a crash round
+// @Description Recompute the crash point of a finished round from its revealed server seed
+// @Tags Crash
+// @Produce json
+// @Param id path string true "Round ID"
+// @Success 200 {object} model.CrashRoundVerifyDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /crash/rounds/{id}/verify [get]
+// @Security BearerAuth
+func (h *CrashHttpHandler) VerifyRound(c *fiber.Ctx) error {
+	result, err := h.service.VerifyRound(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,27 @@
+package crash
+
+import (
+	"context"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+type CrashService interface {
+	GetCurrentRound() (*model.CrashRoundDto, error)
+	PlaceBet(userId string, req *model.PlaceCrashBetRequest) (*model.CrashBetDto, error)
+	CashOut(userId string) (*model.CrashBetDto, error)
+	GetRoundHistory(limit int) ([]model.CrashRoundDto, error)
+	GetBetHistory(userId string, limit int, offset int) ([]model.CrashBetDto, error)
+	VerifyRound(roundId string) (*model.CrashRoundVerifyDto, error)
+	RunEngine(ctx context.Context)
+}
+
+type CrashRepository interface {
+	CreateRound(round *model.CrashRound) error
+	FindRoundById(roundId string) (*model.CrashRound, error)
+	FindLatestRound() (*model.CrashRound, error)
+	FindUnfinishedRoundIds() ([]string, error)
+	FindCrashedRounds(limit int) ([]model.CrashRound, error)
+	FindBetsByRoundId(roundId string) ([]model.CrashBet, error)
+	FindBetsByUserId(userId string, limit int, offset int) ([]model.CrashBet, error)
+}
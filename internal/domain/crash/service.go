@@ -0,0 +1,590 @@
+package crash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type crashServiceImpl struct {
+	repo   CrashRepository
+	userDB *gorm.DB
+	cfg    config.Config
+	log    *zap.Logger
+}
+
+func NewCrashService(repo CrashRepository, db *gorm.DB, cfg config.Config, log *zap.Logger) CrashService {
+	return &crashServiceImpl{
+		repo:   repo,
+		userDB: db,
+		cfg:    cfg,
+		log:    log,
+	}
+}
+
+func (s *crashServiceImpl) GetCurrentRound() (*model.CrashRoundDto, error) {
+	round, err := s.repo.FindLatestRound()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("no crash round has started yet")
+	}
+	if err != nil {
+		s.log.Named("GetCurrentRound").Error("Failed to get round", zap.Error(err))
+		return nil, errors.New("failed to get current round")
+	}
+
+	bets, err := s.repo.FindBetsByRoundId(round.Id)
+	if err != nil {
+		s.log.Named("GetCurrentRound").Error("Failed to get bets", zap.Error(err), zap.String("roundId", round.Id))
+		return nil, errors.New("failed to get current round")
+	}
+
+	return roundToDto(round, bets, time.Now()), nil
+}
+
+func (s *crashServiceImpl) PlaceBet(userId string, req *model.PlaceCrashBetRequest) (*model.CrashBetDto, error) {
+	if err := stakegame.ValidateBetAmount(req.BetAmount); err != nil {
+		return nil, err
+	}
+	if req.AutoCashout < 0 || (req.AutoCashout > 0 && req.AutoCashout <= 1) {
+		return nil, errors.New("auto cash-out must be greater than 1")
+	}
+	if req.AutoCashout > MaxCrashPoint {
+		return nil, errors.New("auto cash-out can never be reached")
+	}
+
+	var bet *model.CrashBet
+
+	err := s.userDB.Transaction(func(tx *gorm.DB) error {
+		user, err := stakegame.LockUser(tx, userId)
+		if err != nil {
+			s.log.Named("PlaceBet").Error("User not found", zap.Error(err))
+			return err
+		}
+
+		// Share lock the round so it cannot start while the bet is being placed
+		var round model.CrashRound
+		if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("status = ?", StatusBetting).
+			Order("created_at DESC").
+			First(&round).Error; err != nil {
+			return errors.New("betting is closed. please wait for the next round")
+		}
+		if !time.Now().Before(round.BettingEndsAt) {
+			return errors.New("betting is closed. please wait for the next round")
+		}
+
+		var betCount int64
+		if err := tx.Model(&model.CrashBet{}).
+			Where("round_id = ? AND user_id = ?", round.Id, userId).
+			Count(&betCount).Error; err != nil {
+			s.log.Named("PlaceBet").Error("Failed to check bets", zap.Error(err))
+			return errors.New("failed to check bets")
+		}
+		if betCount > 0 {
+			return errors.New("you already joined this round")
+		}
+
+		bet = &model.CrashBet{
+			Id:          uuid.New().String(),
+			RoundId:     round.Id,
+			UserId:      userId,
+			BetAmount:   req.BetAmount,
+			AutoCashout: req.AutoCashout,
+			Status:      BetActive,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+
+		if err := tx.Create(bet).Error; err != nil {
+			s.log.Named("PlaceBet").Error("Failed to create bet", zap.Error(err))
+			return errors.New("failed to place bet")
+		}
+
+		if err := stakegame.Debit(tx, user, req.BetAmount); err != nil {
+			s.log.Named("PlaceBet").Warn("Failed to debit bet",
+				zap.Error(err),
+				zap.String("userId", userId),
+				zap.Float64("bet", req.BetAmount))
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Named("PlaceBet").Info("Bet placed",
+		zap.String("roundId", bet.RoundId),
+		zap.String("userId", userId),
+		zap.Float64("bet", bet.BetAmount))
+	return betToDto(bet), nil
+}
+
+func (s *crashServiceImpl) CashOut(userId string) (*model.CrashBetDto, error) {
+	var bet model.CrashBet
+
+	err := s.userDB.Transaction(func(tx *gorm.DB) error {
+		if _, err := stakegame.LockUser(tx, userId); err != nil {
+			s.log.Named("CashOut").Error("User not found", zap.Error(err))
+			return err
+		}
+
+		var round model.CrashRound
+		if err := tx.Where("status = ?", StatusRunning).
+			Order("created_at DESC").
+			First(&round).Error; err != nil {
+			return errors.New("no round is running")
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("round_id = ? AND user_id = ?", round.Id, userId).
+			First(&bet).Error; err != nil {
+			return errors.New("you did not join this round")
+		}
+		if bet.Status != BetActive {
+			return errors.New("you already cashed out this round")
+		}
+
+		now := time.Now()
+		multiplier := MultiplierAt(now.Sub(*round.StartedAt))
+		if multiplier >= round.CrashPoint {
+			return errors.New("too late - the round has crashed")
+		}
+		// An auto cash-out target passed before the request wins over the live multiplier
+		if bet.AutoCashout > 0 && multiplier >= bet.AutoCashout {
+			multiplier = bet.AutoCashout
+		}
+
+		bet.Status = BetCashedOut
+		bet.CashoutMultiplier = multiplier
+		bet.Payout = stakegame.CalculatePayout(bet.BetAmount, multiplier)
+		bet.CashedOutAt = &now
+		bet.UpdatedAt = now
+
+		if err := tx.Save(&bet).Error; err != nil {
+			s.log.Named("CashOut").Error("Failed to update bet", zap.Error(err))
+			return errors.New("failed to update bet")
+		}
+
+		if err := stakegame.Credit(tx, userId, bet.Payout); err != nil {
+			s.log.Named("CashOut").Error("Failed to credit winnings", zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Named("CashOut").Info("Player cashed out",
+		zap.String("roundId", bet.RoundId),
+		zap.String("userId", userId),
+		zap.Float64("multiplier", bet.CashoutMultiplier),
+		zap.Float64("payout", bet.Payout))
+	return betToDto(&bet), nil
+}
+
+func (s *crashServiceImpl) GetRoundHistory(limit int) ([]model.CrashRoundDto, error) {
+	rounds, err := s.repo.FindCrashedRounds(limit)
+	if err != nil {
+		s.log.Named("GetRoundHistory").Error("Failed to get rounds", zap.Error(err))
+		return nil, err
+	}
+
+	now := time.Now()
+	roundDtos := make([]model.CrashRoundDto, len(rounds))
+	for i := range rounds {
+		roundDtos[i] = *roundToDto(&rounds[i], nil, now)
+	}
+	return roundDtos, nil
+}
+
+func (s *crashServiceImpl) GetBetHistory(userId string, limit int, offset int) ([]model.CrashBetDto, error) {
+	bets, err := s.repo.FindBetsByUserId(userId, limit, offset)
+	if err != nil {
+		s.log.Named("GetBetHistory").Error("Failed to get bets", zap.Error(err))
+		return nil, err
+	}
+
+	betDtos := make([]model.CrashBetDto, len(bets))
+	for i := range bets {
+		betDtos[i] = *betToDto(&bets[i])
+	}
+	return betDtos, nil
+}
+
+func (s *crashServiceImpl) VerifyRound(roundId string) (*model.CrashRoundVerifyDto, error) {
+	round, err := s.repo.FindRoundById(roundId)
+	if err != nil {
+		return nil, errors.New("round not found")
+	}
+	if round.Status != StatusCrashed {
+		return nil, errors.New("round can only be verified after it has crashed")
+	}
+
+	computed := CalculateCrashPoint(round.ServerSeed, round.Id, round.HouseEdge)
+	hashMatches := utils.HashServerSeed(round.ServerSeed) == round.ServerSeedHash
+
+	return &model.CrashRoundVerifyDto{
+		RoundId:            round.Id,
+		ServerSeed:         round.ServerSeed,
+		ServerSeedHash:     round.ServerSeedHash,
+		HouseEdge:          round.HouseEdge,
+		CrashPoint:         round.CrashPoint,
+		ComputedCrashPoint: computed,
+		HashMatches:        hashMatches,
+		Verified:           hashMatches && computed == round.CrashPoint,
+	}, nil
+}
+
+// engineLockKey is the Postgres advisory lock held by the one instance that runs crash rounds
+const engineLockKey int64 = 0x63726173680001
+
+// engineLockRetry is how often an instance without the engine lock tries to take it over
+const engineLockRetry = 10 * time.Second
+
+// RunEngine plays rounds back to back until ctx is cancelled: a betting window, a running
+// phase until the crash point is reached, settlement, then a short pause before the next round.
+// Only the instance holding the engine advisory lock plays rounds, the others wait to take over.
+func (s *crashServiceImpl) RunEngine(ctx context.Context) {
+	if !s.cfg.GetGame().CrashEngineEnabled {
+		s.log.Named("RunEngine").Info("Crash engine disabled")
+		return
+	}
+
+	for {
+		conn, acquired, err := s.acquireEngineLock(ctx)
+		if err != nil {
+			s.log.Named("RunEngine").Error("Failed to acquire engine lock", zap.Error(err))
+		}
+		if acquired {
+			s.log.Named("RunEngine").Info("Acquired crash engine lock")
+			s.runRounds(ctx, conn)
+			s.releaseEngineLock(conn)
+		}
+
+		if !sleepContext(ctx, engineLockRetry) {
+			return
+		}
+	}
+}
+
+// acquireEngineLock takes the session advisory lock on a dedicated connection, the lock lives
+// as long as that connection so a crashed instance hands it over automatically
+func (s *crashServiceImpl) acquireEngineLock(ctx context.Context) (*sql.Conn, bool, error) {
+	sqlDB, err := s.userDB.DB()
+	if err != nil {
+		return nil, false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", engineLockKey).Scan(&acquired); err != nil || !acquired {
+		conn.Close()
+		return nil, false, err
+	}
+	return conn, true, nil
+}
+
+func (s *crashServiceImpl) releaseEngineLock(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", engineLockKey); err != nil {
+		s.log.Named("releaseEngineLock").Warn("Failed to release engine lock", zap.Error(err))
+	}
+	conn.Close()
+}
+
+// runRounds plays rounds while the engine lock connection stays alive
+func (s *crashServiceImpl) runRounds(ctx context.Context, lockConn *sql.Conn) {
+	// Rounds left behind by the previous lock holder can no longer be played fairly
+	s.cancelUnfinishedRounds()
+
+	roundDelay := time.Duration(s.cfg.GetGame().CrashRoundDelay) * time.Second
+
+	for {
+		// Losing the connection releases the lock, another instance may already be running rounds
+		if err := lockConn.PingContext(ctx); err != nil {
+			s.log.Named("RunEngine").Error("Lost crash engine lock", zap.Error(err))
+			return
+		}
+
+		round, err := s.openRound()
+		if err != nil {
+			s.log.Named("RunEngine").Error("Failed to open round", zap.Error(err))
+		} else if err := s.playRound(ctx, round); err != nil {
+			s.log.Named("RunEngine").Error("Failed to play round", zap.Error(err), zap.String("roundId", round.Id))
+		}
+
+		if !sleepContext(ctx, roundDelay) {
+			return
+		}
+	}
+}
+
+// openRound commits a new server seed and opens the betting window
+func (s *crashServiceImpl) openRound() (*model.CrashRound, error) {
+	serverSeed, err := utils.GenerateServerSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	houseEdge := s.cfg.GetGame().CrashHouseEdge
+	roundId := uuid.New().String()
+	now := time.Now()
+
+	round := &model.CrashRound{
+		Id:             roundId,
+		Status:         StatusBetting,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: utils.HashServerSeed(serverSeed),
+		CrashPoint:     CalculateCrashPoint(serverSeed, roundId, houseEdge),
+		HouseEdge:      houseEdge,
+		BettingEndsAt:  now.Add(time.Duration(s.cfg.GetGame().CrashBettingWindow) * time.Second),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.repo.CreateRound(round); err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// playRound waits out the betting window, starts the round and settles it once it crashes.
+// A round interrupted by shutdown is left unfinished and cancelled on the next start.
+func (s *crashServiceImpl) playRound(ctx context.Context, round *model.CrashRound) error {
+	if !sleepContext(ctx, time.Until(round.BettingEndsAt)) {
+		return nil
+	}
+
+	startedAt := time.Now()
+	if err := s.updateRoundStatus(round.Id, StatusBetting, StatusRunning, func(r *model.CrashRound) {
+		r.StartedAt = &startedAt
+	}); err != nil {
+		return err
+	}
+
+	crashedAt := startedAt.Add(DurationUntil(round.CrashPoint))
+	if !sleepContext(ctx, time.Until(crashedAt)) {
+		return nil
+	}
+
+	if err := s.updateRoundStatus(round.Id, StatusRunning, StatusCrashed, func(r *model.CrashRound) {
+		r.CrashedAt = &crashedAt
+	}); err != nil {
+		return err
+	}
+
+	settled := s.settleBets(round.Id)
+	s.log.Named("playRound").Info("Round crashed",
+		zap.String("roundId", round.Id),
+		zap.Float64("crashPoint", round.CrashPoint),
+		zap.Int("bets", settled))
+	return nil
+}
+
+// updateRoundStatus moves a round between statuses under a row lock, which waits for bets
+// still being placed on it
+func (s *crashServiceImpl) updateRoundStatus(roundId string, from string, to string, apply func(round *model.CrashRound)) error {
+	return s.userDB.Transaction(func(tx *gorm.DB) error {
+		var round model.CrashRound
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", roundId).
+			First(&round).Error; err != nil {
+			return err
+		}
+		if round.Status != from {
+			return errors.New("round is no longer " + from)
+		}
+
+		round.Status = to
+		round.UpdatedAt = time.Now()
+		if apply != nil {
+			apply(&round)
+		}
+		return tx.Save(&round).Error
+	})
+}
+
+func (s *crashServiceImpl) cancelUnfinishedRounds() {
+	roundIds, err := s.repo.FindUnfinishedRoundIds()
+	if err != nil {
+		s.log.Named("cancelUnfinishedRounds").Error("Failed to find unfinished rounds", zap.Error(err))
+		return
+	}
+
+	for _, roundId := range roundIds {
+		err := s.userDB.Transaction(func(tx *gorm.DB) error {
+			return tx.Model(&model.CrashRound{}).
+				Where("id = ? AND status IN ?", roundId, []string{StatusBetting, StatusRunning}).
+				Updates(map[string]interface{}{"status": StatusCancelled, "updated_at": time.Now()}).Error
+		})
+		if err != nil {
+			s.log.Named("cancelUnfinishedRounds").Error("Failed to cancel round", zap.Error(err), zap.String("roundId", roundId))
+			continue
+		}
+
+		refunded := s.settleBets(roundId)
+		s.log.Named("cancelUnfinishedRounds").Info("Round cancelled", zap.String("roundId", roundId), zap.Int("refunded", refunded))
+	}
+}
+
+// settleBets closes every bet still active on a crashed or cancelled round and returns how many
+// were settled. Each bet is settled in its own transaction locking the user first, like cash-outs do.
+func (s *crashServiceImpl) settleBets(roundId string) int {
+	round, err := s.repo.FindRoundById(roundId)
+	if err != nil {
+		s.log.Named("settleBets").Error("Failed to get round", zap.Error(err), zap.String("roundId", roundId))
+		return 0
+	}
+
+	bets, err := s.repo.FindBetsByRoundId(roundId)
+	if err != nil {
+		s.log.Named("settleBets").Error("Failed to get bets", zap.Error(err), zap.String("roundId", roundId))
+		return 0
+	}
+
+	settled := 0
+	for _, bet := range bets {
+		if bet.Status != BetActive {
+			continue
+		}
+		if err := s.settleBet(round, bet.Id, bet.UserId); err != nil {
+			s.log.Named("settleBets").Error("Failed to settle bet", zap.Error(err), zap.String("betId", bet.Id))
+			continue
+		}
+		settled++
+	}
+	return settled
+}
+
+func (s *crashServiceImpl) settleBet(round *model.CrashRound, betId string, userId string) error {
+	return s.userDB.Transaction(func(tx *gorm.DB) error {
+		if _, err := stakegame.LockUser(tx, userId); err != nil {
+			return err
+		}
+
+		var bet model.CrashBet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", betId).
+			First(&bet).Error; err != nil {
+			return err
+		}
+		if bet.Status != BetActive {
+			return nil
+		}
+
+		now := time.Now()
+		switch {
+		case round.Status == StatusCancelled:
+			bet.Status = BetRefunded
+			bet.Payout = bet.BetAmount
+		case bet.AutoCashout > 0 && bet.AutoCashout < round.CrashPoint:
+			bet.Status = BetCashedOut
+			bet.CashoutMultiplier = bet.AutoCashout
+			bet.Payout = stakegame.CalculatePayout(bet.BetAmount, bet.AutoCashout)
+			cashedOutAt := round.StartedAt.Add(DurationUntil(bet.AutoCashout))
+			bet.CashedOutAt = &cashedOutAt
+		default:
+			bet.Status = BetLost
+			bet.Payout = 0
+		}
+		bet.UpdatedAt = now
+
+		if err := tx.Save(&bet).Error; err != nil {
+			return err
+		}
+
+		return stakegame.Credit(tx, userId, bet.Payout)
+	})
+}
+
+// sleepContext waits for d and reports false if ctx was cancelled first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// roundToDto converts a round to the view every player shares. The crash point and server seed
+// stay hidden until the round has crashed.
+func roundToDto(round *model.CrashRound, bets []model.CrashBet, now time.Time) *model.CrashRoundDto {
+	roundDto := &model.CrashRoundDto{
+		Id:             round.Id,
+		Status:         round.Status,
+		ServerSeedHash: round.ServerSeedHash,
+		Multiplier:     1,
+		BettingEndsAt:  round.BettingEndsAt,
+		StartedAt:      round.StartedAt,
+		CrashedAt:      round.CrashedAt,
+		ServerTime:     now,
+	}
+
+	switch round.Status {
+	case StatusRunning:
+		roundDto.Multiplier = math.Min(MultiplierAt(now.Sub(*round.StartedAt)), round.CrashPoint)
+	case StatusCrashed:
+		roundDto.Multiplier = round.CrashPoint
+		roundDto.CrashPoint = round.CrashPoint
+		roundDto.ServerSeed = round.ServerSeed
+	}
+
+	for i := range bets {
+		betDto := betToDto(&bets[i])
+		betDto.Name = bets[i].User.Name
+		betDto.NickName = bets[i].User.NickName
+
+		// Auto cash-outs are paid when the round settles, show them as soon as they are passed
+		if round.Status == StatusRunning && betDto.Status == BetActive &&
+			betDto.AutoCashout > 0 && roundDto.Multiplier >= betDto.AutoCashout && betDto.AutoCashout < round.CrashPoint {
+			betDto.Status = BetCashedOut
+			betDto.CashoutMultiplier = betDto.AutoCashout
+			betDto.Payout = stakegame.CalculatePayout(betDto.BetAmount, betDto.AutoCashout)
+		}
+		roundDto.Bets = append(roundDto.Bets, *betDto)
+	}
+
+	return roundDto
+}
+
+func betToDto(bet *model.CrashBet) *model.CrashBetDto {
+	return &model.CrashBetDto{
+		Id:                bet.Id,
+		RoundId:           bet.RoundId,
+		UserId:            bet.UserId,
+		BetAmount:         bet.BetAmount,
+		AutoCashout:       bet.AutoCashout,
+		Status:            bet.Status,
+		CashoutMultiplier: bet.CashoutMultiplier,
+		Payout:            bet.Payout,
+		CreatedAt:         bet.CreatedAt,
+		CashedOutAt:       bet.CashedOutAt,
+	}
+}
@@ -0,0 +1,65 @@
+package crash
+
+import (
+	"math"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/utils"
+)
+
+// Round status
+const (
+	StatusBetting   = "betting"
+	StatusRunning   = "running"
+	StatusCrashed   = "crashed"
+	StatusCancelled = "cancelled"
+)
+
+// Bet status
+const (
+	BetActive    = "active"
+	BetCashedOut = "cashed_out"
+	BetLost      = "lost"
+	BetRefunded  = "refunded"
+)
+
+// MaxCrashPoint caps the crash point so payouts fit the coin columns
+const MaxCrashPoint = 10000.0
+
+// growthRate is how fast the multiplier grows per millisecond, it doubles roughly every 11.5 seconds
+const growthRate = 0.00006
+
+// CalculateCrashPoint derives the crash point of a round from its server seed.
+// A uniform number r gives (1 - houseEdge) / (1 - r), floored to 2 decimals, so the
+// chance to reach a multiplier m is (1 - houseEdge) / m.
+func CalculateCrashPoint(serverSeed string, roundId string, houseEdge float64) float64 {
+	r := utils.FairFloats(serverSeed, roundId, 0, 1)[0]
+
+	point := math.Floor((1-houseEdge)/(1-r)*100) / 100
+	if point < 1 {
+		return 1
+	}
+	if point > MaxCrashPoint {
+		return MaxCrashPoint
+	}
+	return point
+}
+
+// MultiplierAt returns the live multiplier after a round has been running for elapsed,
+// every player derives the same value from the round start time
+func MultiplierAt(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 1
+	}
+	ms := float64(elapsed.Milliseconds())
+	return math.Floor(math.Exp(growthRate*ms)*100) / 100
+}
+
+// DurationUntil returns how long a round runs before the multiplier reaches m
+func DurationUntil(m float64) time.Duration {
+	if m <= 1 {
+		return 0
+	}
+	ms := math.Ceil(math.Log(m) / growthRate)
+	return time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,174 @@
+package dice
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DiceHttpHandler struct {
+	service DiceService
+}
+
+func NewDiceHttpHandler(service DiceService) *DiceHttpHandler {
+	return &DiceHttpHandler{service: service}
+}
+
+func (h *DiceHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/dice", mid.AuthMiddleware)
+
+	router.Post("/create", h.CreateGame)
+	router.Get("/history", h.GetHistory)
+	router.Get("/stats", h.GetStats)
+	router.Get("/seed", h.GetSeed)
+	router.Post("/seed/rotate", h.RotateSeed)
+}
+
+// @Summary Play a dice game
+// @Description Bet on a roll from 0.00 to 99.99 landing over or under a target, settled instantly
+// @Tags Dice
+// @Accept json
+// @Produce json
+// @Param request body model.CreateDiceRequest true "Game creation request"
+// @Success 200 {object} model.GameRoundDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /dice/create [post]
+// @Security BearerAuth
+func (h *DiceHttpHandler) CreateGame(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.CreateDiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	game, err := h.service.CreateGame(profile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(game)
+}
+
+// @Summary Get game history
+// @Description Get the user's dice games, newest first
+// @Tags Dice
+// @Produce json
+// @Param limit query int false "Number of games to return (default 20, max 100)"
+// @Param offset query int false "Number of games to skip"
+// @Success 200 {array} model.GameRoundDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /dice/history [get]
+// @Security BearerAuth
+func (h *DiceHttpHandler) GetHistory(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	history, err := h.service.GetGameHistory(profile.Id, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get game history",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":   history,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// @Summary Get user statistics
+// @Description Get statistics for the user's dice games
+// @Tags Dice
+// @Produce json
+// @Success 200 {object} model.GameStatsDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /dice/stats [get]
+// @Security BearerAuth
+func (h *DiceHttpHandler) GetStats(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	stats, err := h.service.GetStats(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get statistics",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+// @Summary Get server seed commitment
+// @Description Get the hashed server seed, client seed and next nonce used for the user's dice games
+// @Tags Dice
+// @Produce json
+// @Success 200 {object} model.GameSeedDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /dice/seed [get]
+// @Security BearerAuth
+func (h *DiceHttpHandler) GetSeed(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	seed, err := h.service.GetSeed(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get server seed",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seed)
+}
+
+// @Summary Rotate server seed
+// @Description Reveal the current server seed and commit a new one with an optional client seed
+// @Tags Dice
+// @Accept json
+// @Produce json
+// @Param request body model.RotateGameSeedRequest false "New client seed"
+// @Success 200 {object} model.RotateGameSeedResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /dice/seed/rotate [post]
+// @Security BearerAuth
+func (h *DiceHttpHandler) RotateSeed(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.RotateGameSeedRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "cannot parse body",
+			})
+		}
+	}
+
+	res, err := h.service.RotateSeed(profile.Id, req.ClientSeed)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
@@ -0,0 +1,13 @@
+package dice
+
+import (
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+type DiceService interface {
+	CreateGame(userId string, req *model.CreateDiceRequest) (*model.GameRoundDto, error)
+	GetGameHistory(userId string, limit int, offset int) ([]model.GameRoundDto, error)
+	GetStats(userId string) (*model.GameStatsDto, error)
+	GetSeed(userId string) (*model.GameSeedDto, error)
+	RotateSeed(userId string, clientSeed string) (*model.RotateGameSeedResponse, error)
+}
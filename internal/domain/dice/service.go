@@ -0,0 +1,65 @@
+package dice
+
+import (
+	"errors"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"go.uber.org/zap"
+)
+
+type diceServiceImpl struct {
+	gameSvc stakegame.GameService
+	cfg     config.Config
+	log     *zap.Logger
+}
+
+func NewDiceService(gameSvc stakegame.GameService, cfg config.Config, log *zap.Logger) DiceService {
+	return &diceServiceImpl{
+		gameSvc: gameSvc,
+		cfg:     cfg,
+		log:     log,
+	}
+}
+
+func (s *diceServiceImpl) CreateGame(userId string, req *model.CreateDiceRequest) (*model.GameRoundDto, error) {
+	if !ValidateDirection(req.Direction) {
+		s.log.Named("CreateGame").Error("Invalid direction", zap.String("direction", req.Direction))
+		return nil, errors.New("invalid direction. must be 'over' or 'under'")
+	}
+
+	target, err := TargetToHundredths(req.Target)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateBet(target, req.Direction); err != nil {
+		return nil, err
+	}
+
+	multiplier := CalculateMultiplier(target, req.Direction, s.cfg.GetGame().DiceHouseEdge)
+
+	return s.gameSvc.Play(&stakegame.Wager{
+		UserId:     userId,
+		Game:       stakegame.GameDice,
+		BetAmount:  req.BetAmount,
+		Choice:     req.Direction + " " + formatHundredths(target),
+		ClientSeed: req.ClientSeed,
+	}, Resolve(target, req.Direction, multiplier))
+}
+
+func (s *diceServiceImpl) GetGameHistory(userId string, limit int, offset int) ([]model.GameRoundDto, error) {
+	return s.gameSvc.GetHistory(userId, stakegame.GameDice, limit, offset)
+}
+
+func (s *diceServiceImpl) GetStats(userId string) (*model.GameStatsDto, error) {
+	return s.gameSvc.GetStats(userId, stakegame.GameDice)
+}
+
+func (s *diceServiceImpl) GetSeed(userId string) (*model.GameSeedDto, error) {
+	return s.gameSvc.GetSeed(userId, stakegame.GameDice)
+}
+
+func (s *diceServiceImpl) RotateSeed(userId string, clientSeed string) (*model.RotateGameSeedResponse, error) {
+	return s.gameSvc.RotateSeed(userId, stakegame.GameDice, clientSeed)
+}
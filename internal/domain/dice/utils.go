@@ -0,0 +1,84 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+)
+
+// Bet directions
+const (
+	DirectionOver  = "over"
+	DirectionUnder = "under"
+)
+
+// Win chance limits, in hundredths of a percent out of the 10000 possible rolls
+const (
+	MinWinOutcomes = 100  // 1%
+	MaxWinOutcomes = 9800 // 98%
+	totalOutcomes  = 10000
+)
+
+// ValidateDirection checks if the direction is valid
+func ValidateDirection(direction string) bool {
+	return direction == DirectionOver || direction == DirectionUnder
+}
+
+// TargetToHundredths converts a target like 49.5 to 4950, rejecting finer precision
+func TargetToHundredths(target float64) (int, error) {
+	hundredths := int(math.Round(target * 100))
+	if math.Abs(float64(hundredths)-target*100) > 1e-6 {
+		return 0, errors.New("target can have at most 2 decimals")
+	}
+	return hundredths, nil
+}
+
+// WinOutcomes counts the rolls from 0.00 to 99.99 that win against the target
+func WinOutcomes(target int, direction string) int {
+	if direction == DirectionUnder {
+		return target
+	}
+	return totalOutcomes - 1 - target
+}
+
+// ValidateBet checks that the win chance of a bet is within limits
+func ValidateBet(target int, direction string) error {
+	if target <= 0 || target >= totalOutcomes {
+		return errors.New("target must be between 0.01 and 99.99")
+	}
+	outcomes := WinOutcomes(target, direction)
+	if outcomes < MinWinOutcomes || outcomes > MaxWinOutcomes {
+		return fmt.Errorf("win chance must be between %d%% and %d%%", MinWinOutcomes/100, MaxWinOutcomes/100)
+	}
+	return nil
+}
+
+// CalculateMultiplier returns (1 - houseEdge) / win chance, floored to 4 decimals
+func CalculateMultiplier(target int, direction string, houseEdge float64) float64 {
+	chance := float64(WinOutcomes(target, direction)) / totalOutcomes
+	return math.Floor((1-houseEdge)/chance*10000) / 10000
+}
+
+// Resolve rolls a number from 0.00 to 99.99 and compares it with the target
+func Resolve(target int, direction string, multiplier float64) stakegame.Resolver {
+	return func(roll float64) stakegame.Outcome {
+		result := int(roll * totalOutcomes)
+
+		won := result > target
+		if direction == DirectionUnder {
+			won = result < target
+		}
+
+		return stakegame.Outcome{
+			Won:        won,
+			Multiplier: multiplier,
+			Result:     formatHundredths(result),
+		}
+	}
+}
+
+func formatHundredths(value int) string {
+	return fmt.Sprintf("%d.%02d", value/100, value%100)
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/esc-chula/intania-888-backend/utils"
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,12 +23,23 @@ func NewEventHttpHandler(eventService EventService) *EventHttpHandler {
 func (h *EventHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
 	router = router.Group("/events", mid.AuthMiddleware)
 
+	router.Get("/daily", h.GetDailyRewardStatus)
 	router.Get("/redeem/daily", h.RedeemDailyReward)
 	router.Post("/spin/slot", h.SpinSlotMachine)
 	router.Post("/use-steal-token", h.UseStealToken)
+	router.Get("/slot/seed", h.GetSlotSeed)
+	router.Post("/slot/seed/rotate", h.RotateSlotSeed)
+	router.Get("/slot/spins", h.GetSlotSpins)
+	router.Get("/slot/spins/:id/verify", h.VerifySlotSpin)
+	router.Get("/lotteries", h.GetLotteries)
+	router.Post("/lotteries/:id/tickets", h.BuyLotteryTickets)
+	router.Get("/lotteries/:id/tickets", h.GetMyLotteryTickets)
+	router.Get("/lotteries/:id/results", h.GetLotteryResult)
 
-	adminRouter := router.Group("", mid.AdminMiddleware)
-	adminRouter.Post("/daily-rewards", h.SetDailyReward)
+	router.Post("/daily-rewards", mid.RequirePermission(model.PermEventConfigure), h.SetDailyReward)
+	router.Get("/slot/config", mid.RequirePermission(model.PermEventConfigure), h.GetSlotConfig)
+	router.Put("/slot/config", mid.RequirePermission(model.PermEventConfigure), h.UpdateSlotConfig)
+	router.Post("/lotteries", mid.RequirePermission(model.PermEventConfigure), h.CreateLottery)
 }
 
 // RedeemDailyReward handles the daily reward redemption
@@ -36,8 +48,8 @@ func (h *EventHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.M
 // @Tags Event
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} map[string]string "redeemed daily reward successful"
-// @Failure 400 {object} map[string]string "not found user profile in context"
+// @Success 200 {object} map[string]interface{} "redeemed daily reward successful, with today's reward and streak"
+// @Failure 400 {object} map[string]string "not found user profile in context or already redeemed today"
 // @Failure 500 {object} map[string]string "internal server error"
 // @Router /events/redeem/daily [get]
 func (h *EventHttpHandler) RedeemDailyReward(c *fiber.Ctx) error {
@@ -47,12 +59,38 @@ func (h *EventHttpHandler) RedeemDailyReward(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errors.New("not found user profile in context").Error()})
 	}
 
-	err := h.eventService.RedeemDailyReward(userProfile)
+	status, err := h.eventService.RedeemDailyReward(userProfile)
+	if errors.Is(err, ErrDailyRewardClaimed) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "redeemed daily reward successful"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "redeemed daily reward successful", "daily": status})
+}
+
+// GetDailyRewardStatus returns today's daily reward for the logged-in user
+// @Summary Get daily reward status
+// @Description Get today's reward amount (Asia/Bangkok date) including the streak bonus, whether it is claimed, and the current streak
+// @Tags Event
+// @Produce json
+// @Success 200 {object} model.DailyRewardStatusDto
+// @Failure 400 {object} map[string]string "not found user profile in context"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /events/daily [get]
+func (h *EventHttpHandler) GetDailyRewardStatus(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errors.New("not found user profile in context").Error()})
+	}
+
+	status, err := h.eventService.GetDailyRewardStatus(userProfile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(status)
 }
 
 func (h *EventHttpHandler) SpinSlotMachine(c *fiber.Ctx) error {
@@ -101,7 +139,7 @@ func (h *EventHttpHandler) SpinSlotMachine(c *fiber.Ctx) error {
 // @Router /events/daily-rewards [post]
 func (h *EventHttpHandler) SetDailyReward(c *fiber.Ctx) error {
 	var req struct {
-		Date   string  `json:"date"`   // Format: DD-MM-YYYY (e.g., "31-10-24")
+		Date   string  `json:"date"`   // Format: DD-MM-YYYY (e.g., "31-10-2024")
 		Amount float64 `json:"amount"` // Reward amount
 	}
 
@@ -117,6 +155,52 @@ func (h *EventHttpHandler) SetDailyReward(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Set daily reward successful"})
 }
 
+// GetSlotConfig returns the slot machine configuration
+// @Summary Get slot config
+// @Description Get the slot machine symbols, tier probabilities and paytable (admin only)
+// @Tags Event
+// @Produce json
+// @Success 200 {object} model.SlotConfigDto
+// @Failure 500 {object} map[string]string "Failed to get slot config"
+// @Router /events/slot/config [get]
+func (h *EventHttpHandler) GetSlotConfig(c *fiber.Ctx) error {
+	config, err := h.eventService.GetSlotConfig()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get slot config"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(config)
+}
+
+// UpdateSlotConfig replaces the slot machine configuration
+// @Summary Update slot config
+// @Description Replace the slot machine symbols, tier probabilities and paytable (admin only). Probabilities of each tier must sum to 1.
+// @Tags Event
+// @Accept json
+// @Produce json
+// @Param request body model.SlotConfigDto true "Slot config"
+// @Success 200 {object} model.SlotConfigDto
+// @Failure 400 {object} map[string]string "Invalid slot config"
+// @Router /events/slot/config [put]
+func (h *EventHttpHandler) UpdateSlotConfig(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	var req model.SlotConfigDto
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	config, err := h.eventService.UpdateSlotConfig(userProfile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(config)
+}
+
 // UseStealToken consumes a steal token to steal a percentage from random users.
 func (h *EventHttpHandler) UseStealToken(c *fiber.Ctx) error {
 	userProfile := utils.GetUserProfileFromCtx(c)
@@ -141,3 +225,225 @@ func (h *EventHttpHandler) UseStealToken(c *fiber.Ctx) error {
 	}
 	return c.Status(fiber.StatusOK).JSON(res)
 }
+
+// GetSlotSeed returns the user's active slot seed commitment
+// @Summary Get slot seed
+// @Description Get the hashed server seed, client seed and next nonce used for the user's slot spins
+// @Tags Event
+// @Produce json
+// @Success 200 {object} model.SlotSeedDto
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /events/slot/seed [get]
+func (h *EventHttpHandler) GetSlotSeed(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	seed, err := h.eventService.GetSlotSeed(userProfile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get slot seed"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seed)
+}
+
+// RotateSlotSeed reveals the user's active slot seed and commits a new one
+// @Summary Rotate slot seed
+// @Description Reveal the current server seed and start a new one with an optional client seed
+// @Tags Event
+// @Accept json
+// @Produce json
+// @Param request body model.RotateSlotSeedRequest false "New client seed"
+// @Success 200 {object} model.RotateSlotSeedResponse
+// @Failure 400 {object} map[string]string "Invalid request payload"
+// @Router /events/slot/seed/rotate [post]
+func (h *EventHttpHandler) RotateSlotSeed(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	var req model.RotateSlotSeedRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+	}
+
+	res, err := h.eventService.RotateSlotSeed(userProfile.Id, req.ClientSeed)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
+
+// GetSlotSpins returns the user's slot spin history
+// @Summary Get slot spin history
+// @Description Get the user's slot spins with their seeds and nonces, newest first
+// @Tags Event
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} model.SlotSpinDto
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /events/slot/spins [get]
+func (h *EventHttpHandler) GetSlotSpins(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	spins, err := h.eventService.GetSlotSpins(userProfile.Id, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get slot spins"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(spins)
+}
+
+// VerifySlotSpin recomputes a spin from its revealed server seed
+// @Summary Verify slot spin
+// @Description Recompute the reels of a spin whose server seed has been revealed by rotation
+// @Tags Event
+// @Produce json
+// @Param id path string true "Spin ID"
+// @Success 200 {object} model.SlotSpinVerifyDto
+// @Failure 400 {object} map[string]string "spin cannot be verified"
+// @Router /events/slot/spins/{id}/verify [get]
+func (h *EventHttpHandler) VerifySlotSpin(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	res, err := h.eventService.VerifySlotSpin(userProfile.Id, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
+
+// CreateLottery schedules a new lottery
+// @Summary Create lottery
+// @Description Schedule a lottery with its ticket price, prize tiers, sales window and draw time (admin only). The hash of the draw seed is published immediately.
+// @Tags Event
+// @Accept json
+// @Produce json
+// @Param request body model.CreateLotteryRequest true "Lottery"
+// @Success 201 {object} model.LotteryDto
+// @Failure 400 {object} map[string]string "Invalid lottery"
+// @Router /events/lotteries [post]
+func (h *EventHttpHandler) CreateLottery(c *fiber.Ctx) error {
+	var req model.CreateLotteryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	lottery, err := h.eventService.CreateLottery(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(lottery)
+}
+
+// GetLotteries lists lotteries
+// @Summary Get lotteries
+// @Description Get the latest lotteries, optionally filtered by status
+// @Tags Event
+// @Produce json
+// @Param status query string false "open or drawn"
+// @Success 200 {array} model.LotteryDto
+// @Failure 400 {object} map[string]string "invalid status"
+// @Router /events/lotteries [get]
+func (h *EventHttpHandler) GetLotteries(c *fiber.Ctx) error {
+	lotteries, err := h.eventService.GetLotteries(c.Query("status"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(lotteries)
+}
+
+// BuyLotteryTickets buys lottery tickets for the logged-in user
+// @Summary Buy lottery tickets
+// @Description Buy tickets for an open lottery. Each ticket gets the next sequential number.
+// @Tags Event
+// @Accept json
+// @Produce json
+// @Param id path string true "Lottery ID"
+// @Param request body model.BuyLotteryTicketsRequest true "Quantity"
+// @Success 201 {array} model.LotteryTicketDto
+// @Failure 400 {object} map[string]string "tickets cannot be bought"
+// @Router /events/lotteries/{id}/tickets [post]
+func (h *EventHttpHandler) BuyLotteryTickets(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	var req model.BuyLotteryTicketsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	tickets, err := h.eventService.BuyLotteryTickets(userProfile.Id, c.Params("id"), req.Quantity)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(tickets)
+}
+
+// GetMyLotteryTickets returns the logged-in user's tickets for a lottery
+// @Summary Get my lottery tickets
+// @Description Get the tickets the user bought for a lottery with their prizes once drawn
+// @Tags Event
+// @Produce json
+// @Param id path string true "Lottery ID"
+// @Success 200 {array} model.LotteryTicketDto
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /events/lotteries/{id}/tickets [get]
+func (h *EventHttpHandler) GetMyLotteryTickets(c *fiber.Ctx) error {
+	userProfile := utils.GetUserProfileFromCtx(c)
+	if userProfile == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User profile not found"})
+	}
+
+	tickets, err := h.eventService.GetMyLotteryTickets(userProfile.Id, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get lottery tickets"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tickets)
+}
+
+// GetLotteryResult returns the winners of a drawn lottery
+// @Summary Get lottery result
+// @Description Get the winning numbers and winners of a drawn lottery with the revealed server seed and client seed to verify the draw
+// @Tags Event
+// @Produce json
+// @Param id path string true "Lottery ID"
+// @Success 200 {object} model.LotteryResultDto
+// @Failure 400 {object} map[string]string "lottery not drawn"
+// @Router /events/lotteries/{id}/results [get]
+func (h *EventHttpHandler) GetLotteryResult(c *fiber.Ctx) error {
+	result, err := h.eventService.GetLotteryResult(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -1,16 +1,25 @@
 package event
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/rand/v2"
 	"time"
 
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
 	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/esc-chula/intania-888-backend/pkg/cache"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+const slotConfigId = "default"
+
+// ErrDailyRewardClaimed is returned when the user already has a claim for the date
+var ErrDailyRewardClaimed = errors.New("already redeemed daily reward")
+
 type eventRepository struct {
 	db    *gorm.DB
 	cache cache.RedisClient
@@ -43,6 +52,418 @@ func (r *eventRepository) SetReward(reward *model.DailyReward) error {
 	return r.db.Save(reward).Error
 }
 
+func (r *eventRepository) GetDailyRewardStreak(userId string) (*model.DailyRewardStreak, error) {
+	var streak model.DailyRewardStreak
+	if err := r.db.First(&streak, "user_id = ?", userId).Error; err != nil {
+		return nil, err
+	}
+	return &streak, nil
+}
+
+func (r *eventRepository) SaveDailyRewardStreak(streak *model.DailyRewardStreak) error {
+	return r.db.Save(streak).Error
+}
+
+// ClaimDailyReward locks the user's streak, lets claim extend it, then records the claim, saves the streak
+// and credits the reward in one transaction, so parallel claims cannot build on the same stale streak.
+// The unique (user, date) index makes a second claim for the same day fail with ErrDailyRewardClaimed.
+func (r *eventRepository) ClaimDailyReward(userId string, claim DailyRewardClaimer) (*model.DailyRewardClaim, error) {
+	var created *model.DailyRewardClaim
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The user is locked before the streak like every other payout, so a claim waits out a season rollover
+		if _, err := stakegame.LockUser(tx, userId); err != nil {
+			return err
+		}
+
+		// First claims have no streak row to lock yet
+		now := time.Now()
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.DailyRewardStreak{UserId: userId, CreatedAt: now, UpdatedAt: now}).Error; err != nil {
+			return err
+		}
+
+		var streak model.DailyRewardStreak
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&streak, "user_id = ?", userId).Error; err != nil {
+			return err
+		}
+
+		c, err := claim(&streak)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(c)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDailyRewardClaimed
+		}
+
+		if err := tx.Save(&streak).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.User{}).
+			Where("id = ?", c.UserId).
+			Update("remaining_coin", gorm.Expr("remaining_coin + ?", c.Reward)).Error; err != nil {
+			return err
+		}
+
+		created = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// --- Slot config repositories ---
+
+func (r *eventRepository) GetSlotConfig() (*model.SlotConfig, error) {
+	var config model.SlotConfig
+	if err := r.db.First(&config, "id = ?", slotConfigId).Error; err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (r *eventRepository) SaveSlotConfig(config *model.SlotConfig) error {
+	config.Id = slotConfigId
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "updated_by", "updated_at"}),
+	}).Create(config).Error
+}
+
+// --- Slot seed repositories ---
+
+func (r *eventRepository) GetActiveSlotSeed(userId string) (*model.SlotSeed, error) {
+	var seed model.SlotSeed
+	if err := r.db.Where("user_id = ? AND is_active = ?", userId, true).First(&seed).Error; err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+func (r *eventRepository) GetSlotSeedById(seedId string) (*model.SlotSeed, error) {
+	var seed model.SlotSeed
+	if err := r.db.First(&seed, "id = ?", seedId).Error; err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+func (r *eventRepository) CreateSlotSeed(seed *model.SlotSeed) error {
+	return r.db.Create(seed).Error
+}
+
+// useSlotSeed locks the user's active seed and bumps its nonce, returning the seed with the nonce to spin with
+func useSlotSeed(tx *gorm.DB, userId string) (*model.SlotSeed, error) {
+	var seed model.SlotSeed
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND is_active = ?", userId, true).
+		First(&seed).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&model.SlotSeed{}).Where("id = ?", seed.Id).
+		Update("nonce", gorm.Expr("nonce + 1")).Error; err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+// RotateSlotSeed reveals the user's active seed and replaces it with next, returning the revealed seed if there was one
+func (r *eventRepository) RotateSlotSeed(userId string, next *model.SlotSeed) (*model.SlotSeed, error) {
+	var previous *model.SlotSeed
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var seed model.SlotSeed
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND is_active = ?", userId, true).
+			First(&seed).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			now := time.Now()
+			if err := tx.Model(&model.SlotSeed{}).Where("id = ?", seed.Id).
+				Updates(map[string]interface{}{"is_active": false, "revealed_at": now}).Error; err != nil {
+				return err
+			}
+			seed.IsActive = false
+			seed.RevealedAt = &now
+			previous = &seed
+		}
+
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// SpinSlot charges the spin, takes the next nonce of the user's seed, pays the reward and stores the spin
+// in one transaction with the user locked, so parallel spins cannot overspend, every charged spin is
+// recorded and rejected spins leave no gap in the nonces
+func (r *eventRepository) SpinSlot(userId string, spendAmount float64, resolve SlotSpinResolver) (*model.SlotSpin, error) {
+	var spin *model.SlotSpin
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		user, err := stakegame.LockUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		if user.RemainingCoin < spendAmount {
+			return errors.New("insufficient coins")
+		}
+
+		balanceBefore := user.RemainingCoin
+		if err := stakegame.Debit(tx, user, spendAmount); err != nil {
+			return err
+		}
+
+		seed, err := useSlotSeed(tx, userId)
+		if err != nil {
+			return errors.New("failed to load slot seed")
+		}
+
+		var token *model.StealToken
+		spin, token, err = resolve(balanceBefore, seed)
+		if err != nil {
+			return err
+		}
+
+		if err := stakegame.Credit(tx, userId, spin.Reward); err != nil {
+			return err
+		}
+		if err := tx.Create(spin).Error; err != nil {
+			return errors.New("failed to record slot spin")
+		}
+		if token != nil {
+			if err := tx.Create(token).Error; err != nil {
+				return errors.New("failed to issue steal token")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spin, nil
+}
+
+func (r *eventRepository) GetSlotSpinById(spinId string) (*model.SlotSpin, error) {
+	var spin model.SlotSpin
+	if err := r.db.Preload("Seed").First(&spin, "id = ?", spinId).Error; err != nil {
+		return nil, err
+	}
+	return &spin, nil
+}
+
+func (r *eventRepository) GetSlotSpinsByUserId(userId string, limit int, offset int) ([]model.SlotSpin, error) {
+	var spins []model.SlotSpin
+	if err := r.db.Preload("Seed").
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&spins).Error; err != nil {
+		return nil, err
+	}
+	return spins, nil
+}
+
+// --- Lottery repositories ---
+
+func (r *eventRepository) CreateLottery(lottery *model.Lottery) error {
+	return r.db.Create(lottery).Error
+}
+
+func (r *eventRepository) GetLotteryById(lotteryId string) (*model.Lottery, error) {
+	var lottery model.Lottery
+	if err := r.db.First(&lottery, "id = ?", lotteryId).Error; err != nil {
+		return nil, err
+	}
+	return &lottery, nil
+}
+
+func (r *eventRepository) GetLotteries(status string, limit int) ([]model.Lottery, error) {
+	var lotteries []model.Lottery
+	query := r.db.Order("draw_at DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&lotteries).Error; err != nil {
+		return nil, err
+	}
+	return lotteries, nil
+}
+
+func (r *eventRepository) GetDueLotteryIds(now time.Time) ([]string, error) {
+	var lotteryIds []string
+	err := r.db.Model(&model.Lottery{}).
+		Where("status = ? AND draw_at <= ?", LotteryOpen, now).
+		Pluck("id", &lotteryIds).Error
+	if err != nil {
+		return nil, err
+	}
+	return lotteryIds, nil
+}
+
+// BuyLotteryTickets charges the user and hands out the next ticket numbers of the lottery.
+// The lottery row is locked so numbers stay sequential across concurrent purchases.
+func (r *eventRepository) BuyLotteryTickets(lotteryId string, userId string, quantity int, now time.Time) ([]model.LotteryTicket, error) {
+	var tickets []model.LotteryTicket
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		user, err := stakegame.LockUser(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		var lottery model.Lottery
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&lottery, "id = ?", lotteryId).Error; err != nil {
+			return errors.New("lottery not found")
+		}
+		if lottery.Status != LotteryOpen || now.Before(lottery.SalesStartAt) || !now.Before(lottery.SalesEndAt) {
+			return errors.New("lottery ticket sales are closed")
+		}
+
+		if lottery.MaxTicketsPerUser > 0 {
+			var owned int64
+			if err := tx.Model(&model.LotteryTicket{}).
+				Where("lottery_id = ? AND user_id = ?", lotteryId, userId).
+				Count(&owned).Error; err != nil {
+				return err
+			}
+			if int(owned)+quantity > lottery.MaxTicketsPerUser {
+				return fmt.Errorf("you can hold at most %d tickets in this lottery", lottery.MaxTicketsPerUser)
+			}
+		}
+
+		if err := stakegame.Debit(tx, user, lottery.TicketPrice*float64(quantity)); err != nil {
+			return err
+		}
+
+		tickets = make([]model.LotteryTicket, quantity)
+		for i := range tickets {
+			tickets[i] = model.LotteryTicket{
+				Id:        uuid.NewString(),
+				LotteryId: lotteryId,
+				Number:    lottery.TicketCount + i + 1,
+				UserId:    userId,
+				CreatedAt: now,
+			}
+		}
+		if err := tx.Create(&tickets).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Lottery{}).Where("id = ?", lotteryId).
+			Updates(map[string]interface{}{
+				"ticket_count": gorm.Expr("ticket_count + ?", quantity),
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// DrawLottery draws a due lottery and pays its winners in one transaction,
+// returning nil when the lottery was already drawn or is not due yet
+func (r *eventRepository) DrawLottery(lotteryId string, now time.Time) (*model.Lottery, error) {
+	var drawn *model.Lottery
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var lottery model.Lottery
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&lottery, "id = ?", lotteryId).Error; err != nil {
+			return err
+		}
+		if lottery.Status != LotteryOpen || now.Before(lottery.DrawAt) {
+			return nil
+		}
+
+		var prizes []model.LotteryPrizeTierDto
+		if err := json.Unmarshal([]byte(lottery.Prizes), &prizes); err != nil {
+			return err
+		}
+
+		clientSeed := LotteryClientSeed(lottery.Id, lottery.TicketCount)
+		winningNumbers := DrawWinningNumbers(lottery.ServerSeed, clientSeed, lottery.TicketCount, len(prizes))
+		pot := LotteryPot(lottery.TicketPrice, lottery.TicketCount, lottery.PotRate)
+
+		for i, number := range winningNumbers {
+			var ticket model.LotteryTicket
+			if err := tx.First(&ticket, "lottery_id = ? AND number = ?", lottery.Id, number).Error; err != nil {
+				return err
+			}
+
+			prize := LotteryPrize(pot, prizes[i])
+			if err := tx.Model(&model.LotteryTicket{}).Where("id = ?", ticket.Id).
+				Updates(map[string]interface{}{"rank": i + 1, "prize": prize}).Error; err != nil {
+				return err
+			}
+			if err := stakegame.Credit(tx, ticket.UserId, prize); err != nil {
+				return err
+			}
+		}
+
+		numbersJSON, err := json.Marshal(winningNumbers)
+		if err != nil {
+			return err
+		}
+
+		lottery.Status = LotteryDrawn
+		lottery.WinningNumbers = string(numbersJSON)
+		lottery.DrawnAt = &now
+		lottery.UpdatedAt = now
+		if err := tx.Save(&lottery).Error; err != nil {
+			return err
+		}
+
+		drawn = &lottery
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drawn, nil
+}
+
+func (r *eventRepository) GetLotteryTicketsByUser(lotteryId string, userId string) ([]model.LotteryTicket, error) {
+	var tickets []model.LotteryTicket
+	err := r.db.Where("lottery_id = ? AND user_id = ?", lotteryId, userId).
+		Order("number ASC").
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *eventRepository) GetLotteryWinners(lotteryId string) ([]model.LotteryTicket, error) {
+	var tickets []model.LotteryTicket
+	err := r.db.Preload("User").
+		Where("lottery_id = ? AND rank > 0", lotteryId).
+		Order("rank ASC").
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
 // --- Steal token repositories ---
 
 func (r *eventRepository) CreateStealToken(token *model.StealToken) error {
@@ -1,12 +1,47 @@
 package event
 
-import "github.com/esc-chula/intania-888-backend/internal/model"
+import (
+	"context"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+// SlotSpinResolver plays a spin with the seed and nonce it was given, against the balance the user had
+// before paying for it. It returns the spin to store and, when the spin won one, the steal token to issue.
+type SlotSpinResolver func(balanceBefore float64, seed *model.SlotSeed) (*model.SlotSpin, *model.StealToken, error)
+
+// DailyRewardClaimer extends the locked streak for today's claim and returns the claim to store
+type DailyRewardClaimer func(streak *model.DailyRewardStreak) (*model.DailyRewardClaim, error)
 
 type EventRepository interface {
 	SetDailyRewardCache(key string, value interface{}, ttl int) error
 	GetDailyRewardCache(key string, value interface{}) error
 	GetReward(date string) (*model.DailyReward, error)
 	SetReward(reward *model.DailyReward) error
+	GetDailyRewardStreak(userId string) (*model.DailyRewardStreak, error)
+	SaveDailyRewardStreak(streak *model.DailyRewardStreak) error
+	ClaimDailyReward(userId string, claim DailyRewardClaimer) (*model.DailyRewardClaim, error)
+
+	GetSlotConfig() (*model.SlotConfig, error)
+	SaveSlotConfig(config *model.SlotConfig) error
+
+	GetActiveSlotSeed(userId string) (*model.SlotSeed, error)
+	GetSlotSeedById(seedId string) (*model.SlotSeed, error)
+	CreateSlotSeed(seed *model.SlotSeed) error
+	RotateSlotSeed(userId string, next *model.SlotSeed) (*model.SlotSeed, error)
+	SpinSlot(userId string, spendAmount float64, resolve SlotSpinResolver) (*model.SlotSpin, error)
+	GetSlotSpinById(spinId string) (*model.SlotSpin, error)
+	GetSlotSpinsByUserId(userId string, limit int, offset int) ([]model.SlotSpin, error)
+
+	CreateLottery(lottery *model.Lottery) error
+	GetLotteryById(lotteryId string) (*model.Lottery, error)
+	GetLotteries(status string, limit int) ([]model.Lottery, error)
+	GetDueLotteryIds(now time.Time) ([]string, error)
+	BuyLotteryTickets(lotteryId string, userId string, quantity int, now time.Time) ([]model.LotteryTicket, error)
+	DrawLottery(lotteryId string, now time.Time) (*model.Lottery, error)
+	GetLotteryTicketsByUser(lotteryId string, userId string) ([]model.LotteryTicket, error)
+	GetLotteryWinners(lotteryId string) ([]model.LotteryTicket, error)
 
 	CreateStealToken(token *model.StealToken) error
 	GetStealTokenByToken(token string) (*model.StealToken, error)
@@ -20,9 +55,26 @@ type EventRepository interface {
 }
 
 type EventService interface {
-	RedeemDailyReward(req *model.UserDto) error
+	RedeemDailyReward(req *model.UserDto) (*model.DailyRewardStatusDto, error)
+	GetDailyRewardStatus(userId string) (*model.DailyRewardStatusDto, error)
 	SpinSlotMachine(req *model.UserDto, spendAmount float64) (map[string]interface{}, error)
 	SetDailyReward(date string, amount float64) error
+	GetSlotConfig() (*model.SlotConfigDto, error)
+	UpdateSlotConfig(adminId string, config *model.SlotConfigDto) (*model.SlotConfigDto, error)
+
+	// Provably fair slot seeds
+	GetSlotSeed(userId string) (*model.SlotSeedDto, error)
+	RotateSlotSeed(userId string, clientSeed string) (*model.RotateSlotSeedResponse, error)
+	GetSlotSpins(userId string, limit int, offset int) ([]model.SlotSpinDto, error)
+	VerifySlotSpin(userId string, spinId string) (*model.SlotSpinVerifyDto, error)
+
+	// Lottery
+	CreateLottery(req *model.CreateLotteryRequest) (*model.LotteryDto, error)
+	GetLotteries(status string) ([]model.LotteryDto, error)
+	BuyLotteryTickets(userId string, lotteryId string, quantity int) ([]model.LotteryTicketDto, error)
+	GetMyLotteryTickets(userId string, lotteryId string) ([]model.LotteryTicketDto, error)
+	GetLotteryResult(lotteryId string) (*model.LotteryResultDto, error)
+	RunLotteryDraws(ctx context.Context)
 
 	// Use steal token
 	UseStealToken(userId string, token string, victimIndex int) (*model.UseStealTokenResponseDto, error)
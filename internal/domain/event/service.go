@@ -1,11 +1,14 @@
 package event
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/esc-chula/intania-888-backend/internal/domain/quest"
 	"github.com/esc-chula/intania-888-backend/internal/domain/user"
 	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/esc-chula/intania-888-backend/pkg/config"
@@ -13,74 +16,171 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+// slotConfigRefreshInterval is how long a loaded slot config is used before it is re-read from the database
+const slotConfigRefreshInterval = 30 * time.Second
+
 type eventService struct {
-	eventRepo EventRepository
-	userRepo  user.UserRepository
-	cfg       config.Config
-	log       *zap.Logger
+	eventRepo    EventRepository
+	userRepo     user.UserRepository
+	questTracker quest.QuestTracker
+	cfg          config.Config
+	log          *zap.Logger
+
+	slotConfigMu       sync.RWMutex
+	slotConfig         *model.SlotConfigDto
+	slotConfigLoadedAt time.Time
 }
 
-func NewEventService(eventRepo EventRepository, userRepo user.UserRepository, cfg config.Config, log *zap.Logger) EventService {
+func NewEventService(eventRepo EventRepository, userRepo user.UserRepository, questTracker quest.QuestTracker, cfg config.Config, log *zap.Logger) EventService {
 	return &eventService{
-		eventRepo: eventRepo,
-		userRepo:  userRepo,
-		cfg:       cfg,
-		log:       log,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		questTracker: questTracker,
+		cfg:          cfg,
+		log:          log,
 	}
 }
 
-func (s *eventService) RedeemDailyReward(req *model.UserDto) error {
+func (s *eventService) RedeemDailyReward(req *model.UserDto) (*model.DailyRewardStatusDto, error) {
 	// is requested user has been already redeemed daily reward or not ?
-	currentTime := time.Now()
-	date := currentTime.Format("02-01-2006")
+	now := utils.BangkokNow()
+	date := utils.BangkokDate(now)
 	key := fmt.Sprintf("%v/%v", date, req.Id)
 
 	var dailyRewardCache model.DailyRewardCacheDto
 
-	// Check if user has already redeemed the reward for today
+	// Fast path: the cache remembers today's claims, the claims table stays the source of truth
 	err := s.eventRepo.GetDailyRewardCache(key, &dailyRewardCache)
-	if err != nil {
-		if err == redis.Nil {
-			// First-time redemption for today: proceed with reward
-			s.log.Named("RedeemDailyReward").Info("First-time redemption for today", zap.String("user_id", req.Id))
-		} else {
-			// Handle other Redis errors
-			s.log.Named("RedeemDailyReward").Error("Get daily reward cache: ", zap.Error(err))
-			return err
-		}
-	} else {
-		// User has already redeemed the reward today
+	if err == nil {
 		s.log.Named("RedeemDailyReward").Info("Already redeemed daily reward", zap.String("user_id", req.Id))
-		return errors.New("already redeemed daily reward")
+		return nil, ErrDailyRewardClaimed
+	}
+	if err != redis.Nil {
+		s.log.Named("RedeemDailyReward").Warn("Get daily reward cache: ", zap.Error(err))
 	}
 
-	// Set value of daily reward to 300 coins
-	dailyReward := 300.00
+	baseReward, err := s.dailyRewardAmount(date)
+	if err != nil {
+		return nil, err
+	}
+
+	// The multiplier is worked out from the streak as locked inside the claim transaction
+	var status *model.DailyRewardStatusDto
+	claim, err := s.eventRepo.ClaimDailyReward(req.Id, func(streak *model.DailyRewardStreak) (*model.DailyRewardClaim, error) {
+		status = streakStatus(streak, now, baseReward)
+		if status.Claimed {
+			return nil, ErrDailyRewardClaimed
+		}
 
-	// Update the user's coin balance
-	user, err := s.userRepo.GetById(req.Id)
+		// Extend the streak, status already holds the day of the streak being claimed
+		streak.CurrentStreak = status.Streak + 1
+		if streak.CurrentStreak > streak.LongestStreak {
+			streak.LongestStreak = streak.CurrentStreak
+		}
+		streak.LastClaimDate = date
+		streak.LastReward = status.Reward
+		streak.UpdatedAt = time.Now()
+
+		status.Claimed = true
+		status.Streak = streak.CurrentStreak
+		status.LongestStreak = streak.LongestStreak
+
+		return &model.DailyRewardClaim{
+			Id:        uuid.New().String(),
+			UserId:    req.Id,
+			Date:      date,
+			Reward:    status.Reward,
+			Streak:    streak.CurrentStreak,
+			CreatedAt: time.Now(),
+		}, nil
+	})
 	if err != nil {
-		s.log.Named("RedeemDailyReward").Error("Get user by Id: ", zap.Error(err))
-		return err
+		if errors.Is(err, ErrDailyRewardClaimed) {
+			s.log.Named("RedeemDailyReward").Info("Already redeemed daily reward", zap.String("user_id", req.Id))
+			if status != nil && status.Reward > 0 {
+				s.cacheDailyRewardClaim(key, req.Id, status.Reward, now)
+			}
+			return nil, err
+		}
+		s.log.Named("RedeemDailyReward").Error("Claim daily reward: ", zap.Error(err))
+		return nil, err
+	}
+
+	s.cacheDailyRewardClaim(key, req.Id, claim.Reward, now)
+	return status, nil
+}
+
+// cacheDailyRewardClaim remembers a claim until the Asia/Bangkok day ends, failures only cost the fast path
+func (s *eventService) cacheDailyRewardClaim(key string, userId string, reward float64, now time.Time) {
+	dailyRewardCache := model.DailyRewardCacheDto{UserId: userId, Reward: reward}
+	if err := s.eventRepo.SetDailyRewardCache(key, dailyRewardCache, utils.SecondsUntilBangkokMidnight(now)); err != nil {
+		s.log.Named("RedeemDailyReward").Warn("Set daily reward cache: ", zap.Error(err))
+	}
+}
+
+func (s *eventService) GetDailyRewardStatus(userId string) (*model.DailyRewardStatusDto, error) {
+	now := utils.BangkokNow()
+
+	streak, err := s.eventRepo.GetDailyRewardStreak(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		streak = &model.DailyRewardStreak{UserId: userId, CreatedAt: time.Now()}
+	} else if err != nil {
+		s.log.Named("GetDailyRewardStatus").Error("Get daily reward streak: ", zap.Error(err))
+		return nil, err
 	}
-	user.RemainingCoin += dailyReward
 
-	err = s.userRepo.Update(user)
+	baseReward, err := s.dailyRewardAmount(utils.BangkokDate(now))
 	if err != nil {
-		s.log.Named("RedeemDailyReward").Error("Update user: ", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	// Set the cache for daily reward redemption
-	dailyRewardCache = model.DailyRewardCacheDto{UserId: req.Id, Reward: dailyReward}
-	if err := s.eventRepo.SetDailyRewardCache(key, dailyRewardCache, s.cfg.GetJwt().RefreshTokenExpiration); err != nil {
-		s.log.Named("RedeemDailyReward").Error("Set daily reward cache: ", zap.Error(err))
-		return err
+	return streakStatus(streak, now, baseReward), nil
+}
+
+// streakStatus returns today's reward for a user with the given streak record.
+// When today is not claimed yet, the reward already includes the multiplier of the day it would add to the streak.
+func streakStatus(streak *model.DailyRewardStreak, now time.Time, baseReward float64) *model.DailyRewardStatusDto {
+	date := utils.BangkokDate(now)
+	yesterday := utils.BangkokDate(now.AddDate(0, 0, -1))
+
+	status := &model.DailyRewardStatusDto{
+		Date:          date,
+		BaseReward:    baseReward,
+		LongestStreak: streak.LongestStreak,
 	}
 
-	return nil
+	switch streak.LastClaimDate {
+	case date:
+		status.Claimed = true
+		status.Streak = streak.CurrentStreak
+		status.Multiplier = StreakMultiplier(streak.CurrentStreak)
+		status.Reward = streak.LastReward
+		return status
+	case yesterday:
+		status.Streak = streak.CurrentStreak
+	default:
+		status.Streak = 0
+	}
+
+	status.Multiplier = StreakMultiplier(status.Streak + 1)
+	status.Reward = roundToTwoDecimals(baseReward * status.Multiplier)
+	return status
+}
+
+// dailyRewardAmount returns the reward admins configured for the date, or the default amount
+func (s *eventService) dailyRewardAmount(date string) (float64, error) {
+	reward, err := s.eventRepo.GetReward(date)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.cfg.GetGame().DailyRewardDefault, nil
+	}
+	if err != nil {
+		s.log.Named("dailyRewardAmount").Error("Get reward: ", zap.Error(err))
+		return 0, err
+	}
+	return reward.Reward, nil
 }
 
 func (s *eventService) SpinSlotMachine(req *model.UserDto, spendAmount float64) (map[string]interface{}, error) {
@@ -88,123 +188,281 @@ func (s *eventService) SpinSlotMachine(req *model.UserDto, spendAmount float64)
 		s.log.Named("SpinSlotMachine").Warn("failed to cleanup expired tokens", zap.Error(err))
 	}
 
-	user, err := s.userRepo.GetById(req.Id)
+	// The seed hash must be committed before the spin, the spin transaction takes its next nonce
+	if _, err := s.getActiveSlotSeed(req.Id); err != nil {
+		s.log.Named("SpinSlotMachine").Error("Get active slot seed", zap.Error(err))
+		return nil, errors.New("failed to load slot seed")
+	}
+
+	slotConfig := s.getSlotConfig()
+	var reels []string
+	var token *model.StealToken
+	var previews []model.CandidatePreviewDto
+
+	spin, err := s.eventRepo.SpinSlot(req.Id, spendAmount, func(balanceBefore float64, seed *model.SlotSeed) (*model.SlotSpin, *model.StealToken, error) {
+		// Spin the slots
+		tier := utils.GetSlotTier(slotConfig, balanceBefore)
+		reels = utils.GetFairSlotReels(tier.Symbols, seed.ServerSeed, seed.ClientSeed, seed.Nonce)
+
+		// Calculate reward based on the configured paytable
+		var reward float64
+		rule := utils.MatchSlotPaytable(slotConfig, reels)
+		if rule != nil {
+			reward = spendAmount * rule.Multiplier
+		}
+
+		// issue steal token, falling back to the rule multiplier if no token can be issued
+		if rule != nil && rule.Action == utils.SlotActionStealToken {
+			token, previews = s.newStealToken(req.Id)
+			if token != nil {
+				reward = 0
+			}
+		}
+
+		spin, err := s.newSlotSpin(req.Id, seed, tier, reels, rule, spendAmount, roundToTwoDecimals(reward))
+		return spin, token, err
+	})
 	if err != nil {
+		s.log.Named("SpinSlotMachine").Warn("Spin slot", zap.Error(err), zap.String("user_id", req.Id))
 		return nil, err
 	}
 
-	if user.RemainingCoin < spendAmount {
-		return nil, errors.New("insufficient coins")
+	s.questTracker.Track(req.Id, &quest.Event{Type: quest.EventSlotSpin})
+
+	// Return result to frontend
+	result := map[string]interface{}{
+		"slots":          reels,
+		"reward":         spin.Reward,
+		"spinId":         spin.Id,
+		"serverSeedHash": spin.ServerSeedHash,
+		"nonce":          spin.Nonce,
+	}
+	if token != nil {
+		result["stealToken"] = model.StealTokenDto{
+			Token:       token.Token,
+			ExpiresAt:   token.ExpiresAt,
+			VictimCount: 3,
+			Message:     "👽 ALIEN POWER! Use this token to steal from other players!",
+		}
+		result["candidates"] = previews
+	}
+	return result, nil
+}
+
+// newStealToken picks 3 candidates and stores their IDs in a token, nil when nobody is eligible
+func (s *eventService) newStealToken(userId string) (*model.StealToken, []model.CandidatePreviewDto) {
+	candidates, err := s.eventRepo.GetRandomEligibleUsers(userId, 3)
+	if err != nil || len(candidates) == 0 {
+		s.log.Named("SpinSlotMachine").Error("No eligible candidates", zap.Error(err))
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(candidates))
+	previews := make([]model.CandidatePreviewDto, 0, len(candidates))
+	for i, u := range candidates {
+		ids = append(ids, u.Id)
+		previews = append(previews, model.CandidatePreviewDto{Index: i, Name: u.Name, RoleId: u.RoleId, GroupId: u.GroupId})
+	}
+
+	return &model.StealToken{
+		Id:               uuid.NewString(),
+		UserId:           userId,
+		Token:            uuid.NewString(),
+		IsUsed:           false,
+		AllowedVictimIds: joinCSV(ids),
+		ExpiresAt:        time.Now().Add(60 * time.Second),
+	}, previews
+}
+
+// newSlotSpin builds the record that lets the spin be verified later
+func (s *eventService) newSlotSpin(userId string, seed *model.SlotSeed, tier *model.SlotTierDto, reels []string, rule *model.SlotPayRuleDto, spendAmount float64, reward float64) (*model.SlotSpin, error) {
+	symbols, err := json.Marshal(tier.Symbols)
+	if err != nil {
+		s.log.Named("newSlotSpin").Error("Marshal tier symbols", zap.Error(err))
+		return nil, errors.New("failed to record slot spin")
+	}
+
+	spin := &model.SlotSpin{
+		Id:             uuid.NewString(),
+		UserId:         userId,
+		SeedId:         seed.Id,
+		ServerSeedHash: seed.ServerSeedHash,
+		ClientSeed:     seed.ClientSeed,
+		Nonce:          seed.Nonce,
+		Reels:          joinCSV(reels),
+		Symbols:        string(symbols),
+		SpendAmount:    spendAmount,
+		Reward:         reward,
+		CreatedAt:      time.Now(),
 	}
+	if rule != nil {
+		spin.RuleName = rule.Name
+	}
+	return spin, nil
+}
 
-	user.RemainingCoin -= spendAmount
-	err = s.userRepo.Update(user)
+func (s *eventService) GetSlotSeed(userId string) (*model.SlotSeedDto, error) {
+	seed, err := s.getActiveSlotSeed(userId)
 	if err != nil {
+		s.log.Named("GetSlotSeed").Error("Get active slot seed", zap.Error(err))
 		return nil, err
 	}
 
-	// Spin the slots
-	slot1 := utils.GetRandomSlot(req)
-	slot2 := utils.GetRandomSlot(req)
-	slot3 := utils.GetRandomSlot(req)
-
-	// Calculate reward based on new rules
-	var reward float64
-	switch {
-	// 3 matching aliens -> issue steal token
-	case slot1 == "👽" && slot2 == "👽" && slot3 == "👽":
-		// pick 3 candidates and store their IDs in token
-		candidates, err := s.eventRepo.GetRandomEligibleUsers(req.Id, 3)
-		if err != nil || len(candidates) == 0 {
-			s.log.Named("SpinSlotMachine").Error("No eligible candidates", zap.Error(err))
-			reward = spendAmount * 4.0
-			break
-		}
+	return slotSeedToDto(seed), nil
+}
 
-		ids := make([]string, 0, len(candidates))
-		for _, u := range candidates {
-			ids = append(ids, u.Id)
-		}
+func (s *eventService) RotateSlotSeed(userId string, clientSeed string) (*model.RotateSlotSeedResponse, error) {
+	if len(clientSeed) > 64 {
+		return nil, errors.New("client seed cannot be longer than 64 characters")
+	}
+
+	next, err := newSlotSeed(userId, clientSeed)
+	if err != nil {
+		s.log.Named("RotateSlotSeed").Error("Generate slot seed", zap.Error(err))
+		return nil, err
+	}
+
+	previous, err := s.eventRepo.RotateSlotSeed(userId, next)
+	if err != nil {
+		s.log.Named("RotateSlotSeed").Error("Rotate slot seed", zap.Error(err))
+		return nil, err
+	}
 
-		token := &model.StealToken{
-			Id:               uuid.NewString(),
-			UserId:           req.Id,
-			Token:            uuid.NewString(),
-			IsUsed:           false,
-			AllowedVictimIds: joinCSV(ids),
-			ExpiresAt:        time.Now().Add(60 * time.Second),
+	res := &model.RotateSlotSeedResponse{Current: *slotSeedToDto(next)}
+	if previous != nil {
+		res.Previous = &model.RevealedSlotSeedDto{
+			ServerSeed:     previous.ServerSeed,
+			ServerSeedHash: previous.ServerSeedHash,
+			ClientSeed:     previous.ClientSeed,
+			SpinCount:      previous.Nonce,
+			RevealedAt:     previous.RevealedAt,
 		}
-		if err := s.eventRepo.CreateStealToken(token); err != nil {
-			s.log.Named("SpinSlotMachine").Error("Failed to create steal token", zap.Error(err))
-			reward = spendAmount * 4.0
-		} else {
-			previews := make([]model.CandidatePreviewDto, 0, len(candidates))
-			for i, u := range candidates {
-				previews = append(previews, model.CandidatePreviewDto{Index: i, Name: u.Name, RoleId: u.RoleId, GroupId: u.GroupId})
-			}
+	}
+
+	s.log.Named("RotateSlotSeed").Info("Rotated slot seed", zap.String("user_id", userId))
+	return res, nil
+}
+
+func (s *eventService) GetSlotSpins(userId string, limit int, offset int) ([]model.SlotSpinDto, error) {
+	spins, err := s.eventRepo.GetSlotSpinsByUserId(userId, limit, offset)
+	if err != nil {
+		s.log.Named("GetSlotSpins").Error("Get slot spins", zap.Error(err))
+		return nil, err
+	}
 
-			return map[string]interface{}{
-				"slots":  []string{slot1, slot2, slot3},
-				"reward": 0.0,
-				"stealToken": model.StealTokenDto{
-					Token:       token.Token,
-					ExpiresAt:   token.ExpiresAt,
-					VictimCount: 3,
-					Message:     "👽 ALIEN POWER! Use this token to steal from other players!",
-				},
-				"candidates": previews,
-			}, nil
+	res := make([]model.SlotSpinDto, len(spins))
+	for i, spin := range spins {
+		res[i] = model.SlotSpinDto{
+			Id:             spin.Id,
+			ServerSeedHash: spin.ServerSeedHash,
+			ClientSeed:     spin.ClientSeed,
+			Nonce:          spin.Nonce,
+			Reels:          splitCSV(spin.Reels),
+			RuleName:       spin.RuleName,
+			SpendAmount:    spin.SpendAmount,
+			Reward:         spin.Reward,
+			CreatedAt:      spin.CreatedAt,
 		}
-	// 3 matching gold symbols
-	case slot1 == "💰" && slot2 == "💰" && slot3 == "💰":
-		reward = spendAmount * 10.0
-
-	// 3 matching fruit symbols
-	case slot1 == slot2 && slot2 == slot3:
-		reward = spendAmount * 4.0
-
-	// 2 gold + 1 different symbol
-	case (slot1 == "💰" && slot2 == "💰" && slot3 != "💰") ||
-		(slot1 == "💰" && slot3 == "💰" && slot2 != "💰") ||
-		(slot2 == "💰" && slot3 == "💰" && slot1 != "💰"):
-		reward = spendAmount * 3.0
-
-	// 1 gold + 2 matching symbols
-	case (slot1 == "💰" && slot2 == slot3 && slot2 != "💰") ||
-		(slot2 == "💰" && slot1 == slot3 && slot1 != "💰") ||
-		(slot3 == "💰" && slot1 == slot2 && slot1 != "💰"):
-		reward = spendAmount * 2.0
-
-	// 1 gold + 2 different symbols
-	case (slot1 == "💰" && slot2 != "💰" && slot3 != "💰") ||
-		(slot2 == "💰" && slot1 != "💰" && slot3 != "💰") ||
-		(slot3 == "💰" && slot1 != "💰" && slot2 != "💰"):
-		reward = spendAmount * 1.5
-
-	// 2 matching fruit symbols
-	case slot1 == slot2 || slot1 == slot3 || slot2 == slot3:
-		reward = spendAmount * 0.75
+		if !spin.Seed.IsActive {
+			res[i].ServerSeed = spin.Seed.ServerSeed
+		}
+	}
 
-	default:
-		reward = 0
+	return res, nil
+}
+
+func (s *eventService) VerifySlotSpin(userId string, spinId string) (*model.SlotSpinVerifyDto, error) {
+	spin, err := s.eventRepo.GetSlotSpinById(spinId)
+	if err != nil {
+		return nil, errors.New("spin not found")
+	}
+	if spin.UserId != userId {
+		return nil, errors.New("spin not found")
+	}
+	if spin.Seed.IsActive {
+		return nil, errors.New("server seed is still in use. rotate your seed to verify this spin")
+	}
+
+	var symbols []model.SlotSymbolDto
+	if err := json.Unmarshal([]byte(spin.Symbols), &symbols); err != nil {
+		s.log.Named("VerifySlotSpin").Error("Unmarshal spin symbols", zap.Error(err))
+		return nil, errors.New("failed to load spin data")
+	}
+
+	reels := splitCSV(spin.Reels)
+	computed := utils.GetFairSlotReels(symbols, spin.Seed.ServerSeed, spin.ClientSeed, spin.Nonce)
+	reelsMatch := len(reels) == len(computed)
+	for i := 0; reelsMatch && i < len(reels); i++ {
+		reelsMatch = reels[i] == computed[i]
 	}
 
-	reward = roundToTwoDecimals(reward)
+	return &model.SlotSpinVerifyDto{
+		SpinId:         spin.Id,
+		ServerSeed:     spin.Seed.ServerSeed,
+		ServerSeedHash: spin.ServerSeedHash,
+		ClientSeed:     spin.ClientSeed,
+		Nonce:          spin.Nonce,
+		Symbols:        symbols,
+		Reels:          reels,
+		ComputedReels:  computed,
+		HashMatches:    utils.HashServerSeed(spin.Seed.ServerSeed) == spin.ServerSeedHash,
+		ReelsMatch:     reelsMatch,
+	}, nil
+}
+
+// getActiveSlotSeed returns the user's active seed, committing a new one on their first spin
+func (s *eventService) getActiveSlotSeed(userId string) (*model.SlotSeed, error) {
+	seed, err := s.eventRepo.GetActiveSlotSeed(userId)
+	if err == nil {
+		return seed, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
 
-	// Add reward to user's balance
-	user.RemainingCoin += reward
-	err = s.userRepo.Update(user)
+	seed, err = newSlotSeed(userId, "")
 	if err != nil {
 		return nil, err
 	}
+	if err := s.eventRepo.CreateSlotSeed(seed); err != nil {
+		// a concurrent request may have committed one first
+		return s.eventRepo.GetActiveSlotSeed(userId)
+	}
+	return seed, nil
+}
 
-	// Return result to frontend
-	return map[string]interface{}{
-		"slots":  []string{slot1, slot2, slot3},
-		"reward": reward,
+func newSlotSeed(userId string, clientSeed string) (*model.SlotSeed, error) {
+	serverSeed, err := utils.GenerateServerSeed()
+	if err != nil {
+		return nil, err
+	}
+	if clientSeed == "" {
+		if clientSeed, err = utils.GenerateClientSeed(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.SlotSeed{
+		Id:             uuid.NewString(),
+		UserId:         userId,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: utils.HashServerSeed(serverSeed),
+		ClientSeed:     clientSeed,
+		IsActive:       true,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}, nil
 }
 
+func slotSeedToDto(seed *model.SlotSeed) *model.SlotSeedDto {
+	return &model.SlotSeedDto{
+		ServerSeedHash: seed.ServerSeedHash,
+		ClientSeed:     seed.ClientSeed,
+		Nonce:          seed.Nonce,
+		CreatedAt:      seed.CreatedAt,
+	}
+}
+
 func (s *eventService) UseStealToken(userId string, token string, victimIndex int) (*model.UseStealTokenResponseDto, error) {
 	stealToken, err := s.eventRepo.GetStealTokenByToken(token)
 	if err != nil {
@@ -339,6 +597,87 @@ func (s *eventService) SetDailyReward(date string, amount float64) error {
 	return nil
 }
 
+func (s *eventService) GetSlotConfig() (*model.SlotConfigDto, error) {
+	return s.getSlotConfig(), nil
+}
+
+func (s *eventService) UpdateSlotConfig(adminId string, config *model.SlotConfigDto) (*model.SlotConfigDto, error) {
+	if err := utils.ValidateSlotConfig(config); err != nil {
+		s.log.Named("UpdateSlotConfig").Warn("Invalid slot config", zap.Error(err))
+		return nil, err
+	}
+
+	config.UpdatedBy = adminId
+	config.UpdatedAt = nil
+	data, err := json.Marshal(config)
+	if err != nil {
+		s.log.Named("UpdateSlotConfig").Error("Marshal slot config", zap.Error(err))
+		return nil, err
+	}
+
+	entity := &model.SlotConfig{Data: string(data), UpdatedBy: adminId}
+	if err := s.eventRepo.SaveSlotConfig(entity); err != nil {
+		s.log.Named("UpdateSlotConfig").Error("Save slot config", zap.Error(err))
+		return nil, err
+	}
+
+	// reload right away so this instance serves the new config on the next spin
+	s.slotConfigMu.Lock()
+	s.slotConfigLoadedAt = time.Time{}
+	s.slotConfigMu.Unlock()
+
+	s.log.Named("UpdateSlotConfig").Info("Updated slot config", zap.String("admin_id", adminId))
+	return s.getSlotConfig(), nil
+}
+
+// getSlotConfig returns the cached slot config, reloading it from the database once it gets stale
+func (s *eventService) getSlotConfig() *model.SlotConfigDto {
+	s.slotConfigMu.RLock()
+	if s.slotConfig != nil && time.Since(s.slotConfigLoadedAt) < slotConfigRefreshInterval {
+		defer s.slotConfigMu.RUnlock()
+		return s.slotConfig
+	}
+	s.slotConfigMu.RUnlock()
+
+	s.slotConfigMu.Lock()
+	defer s.slotConfigMu.Unlock()
+
+	config, err := s.loadSlotConfig()
+	if err != nil {
+		s.log.Named("getSlotConfig").Error("Load slot config, keeping previous one", zap.Error(err))
+		if s.slotConfig == nil {
+			s.slotConfig = utils.DefaultSlotConfig()
+		}
+	} else {
+		s.slotConfig = config
+	}
+	s.slotConfigLoadedAt = time.Now()
+
+	return s.slotConfig
+}
+
+func (s *eventService) loadSlotConfig() (*model.SlotConfigDto, error) {
+	entity, err := s.eventRepo.GetSlotConfig()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.DefaultSlotConfig(), nil
+		}
+		return nil, err
+	}
+
+	var config model.SlotConfigDto
+	if err := json.Unmarshal([]byte(entity.Data), &config); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateSlotConfig(&config); err != nil {
+		return nil, err
+	}
+	config.UpdatedBy = entity.UpdatedBy
+	config.UpdatedAt = &entity.UpdatedAt
+
+	return &config, nil
+}
+
 // joinCSV joins a slice of strings into a comma-separated string.
 func joinCSV(ids []string) string {
 	if len(ids) == 0 {
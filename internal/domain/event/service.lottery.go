@@ -0,0 +1,246 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// lotteryDrawInterval is how often due lotteries are looked for
+const lotteryDrawInterval = 30 * time.Second
+
+// lotteryListLimit is the most lotteries returned by a listing
+const lotteryListLimit = 20
+
+func (s *eventService) CreateLottery(req *model.CreateLotteryRequest) (*model.LotteryDto, error) {
+	if req.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if req.TicketPrice <= 0 {
+		return nil, errors.New("ticket price must be greater than 0")
+	}
+	if req.PotRate < 0 || req.PotRate > 1 {
+		return nil, errors.New("pot rate must be between 0 and 1")
+	}
+	if req.MaxTicketsPerUser < 0 {
+		return nil, errors.New("max tickets per user cannot be negative")
+	}
+	if err := ValidateLotteryPrizes(req.Prizes); err != nil {
+		return nil, err
+	}
+	if !req.SalesEndAt.After(req.SalesStartAt) {
+		return nil, errors.New("ticket sales must end after they start")
+	}
+	if req.DrawAt.Before(req.SalesEndAt) {
+		return nil, errors.New("draw cannot happen before ticket sales end")
+	}
+
+	prizesJSON, err := json.Marshal(req.Prizes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit the server seed now, its hash is public while tickets are sold
+	serverSeed, err := utils.GenerateServerSeed()
+	if err != nil {
+		s.log.Named("CreateLottery").Error("Generate server seed", zap.Error(err))
+		return nil, errors.New("failed to generate server seed")
+	}
+
+	lottery := &model.Lottery{
+		Id:                uuid.NewString(),
+		Title:             req.Title,
+		TicketPrice:       req.TicketPrice,
+		PotRate:           req.PotRate,
+		MaxTicketsPerUser: req.MaxTicketsPerUser,
+		Prizes:            string(prizesJSON),
+		Status:            LotteryOpen,
+		ServerSeed:        serverSeed,
+		ServerSeedHash:    utils.HashServerSeed(serverSeed),
+		SalesStartAt:      req.SalesStartAt,
+		SalesEndAt:        req.SalesEndAt,
+		DrawAt:            req.DrawAt,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
+	if err := s.eventRepo.CreateLottery(lottery); err != nil {
+		s.log.Named("CreateLottery").Error("Create lottery", zap.Error(err))
+		return nil, errors.New("failed to create lottery")
+	}
+
+	s.log.Named("CreateLottery").Info("Created lottery", zap.String("lottery_id", lottery.Id), zap.Time("draw_at", lottery.DrawAt))
+	return lotteryToDto(lottery), nil
+}
+
+func (s *eventService) GetLotteries(status string) ([]model.LotteryDto, error) {
+	if status != "" && status != LotteryOpen && status != LotteryDrawn {
+		return nil, errors.New("invalid status. must be 'open' or 'drawn'")
+	}
+
+	lotteries, err := s.eventRepo.GetLotteries(status, lotteryListLimit)
+	if err != nil {
+		s.log.Named("GetLotteries").Error("Get lotteries", zap.Error(err))
+		return nil, err
+	}
+
+	lotteryDtos := make([]model.LotteryDto, len(lotteries))
+	for i := range lotteries {
+		lotteryDtos[i] = *lotteryToDto(&lotteries[i])
+	}
+	return lotteryDtos, nil
+}
+
+func (s *eventService) BuyLotteryTickets(userId string, lotteryId string, quantity int) ([]model.LotteryTicketDto, error) {
+	if quantity < 1 || quantity > 100 {
+		return nil, errors.New("quantity must be between 1 and 100")
+	}
+
+	tickets, err := s.eventRepo.BuyLotteryTickets(lotteryId, userId, quantity, time.Now())
+	if err != nil {
+		s.log.Named("BuyLotteryTickets").Warn("Buy lottery tickets", zap.Error(err),
+			zap.String("lottery_id", lotteryId),
+			zap.String("user_id", userId))
+		return nil, err
+	}
+
+	s.log.Named("BuyLotteryTickets").Info("Bought lottery tickets",
+		zap.String("lottery_id", lotteryId),
+		zap.String("user_id", userId),
+		zap.Int("quantity", quantity))
+	return ticketsToDto(tickets), nil
+}
+
+func (s *eventService) GetMyLotteryTickets(userId string, lotteryId string) ([]model.LotteryTicketDto, error) {
+	tickets, err := s.eventRepo.GetLotteryTicketsByUser(lotteryId, userId)
+	if err != nil {
+		s.log.Named("GetMyLotteryTickets").Error("Get lottery tickets", zap.Error(err))
+		return nil, err
+	}
+	return ticketsToDto(tickets), nil
+}
+
+// GetLotteryResult returns the winners of a drawn lottery with everything needed to replay the draw
+func (s *eventService) GetLotteryResult(lotteryId string) (*model.LotteryResultDto, error) {
+	lottery, err := s.eventRepo.GetLotteryById(lotteryId)
+	if err != nil {
+		return nil, errors.New("lottery not found")
+	}
+	if lottery.Status != LotteryDrawn {
+		return nil, errors.New("lottery has not been drawn yet")
+	}
+
+	var winningNumbers []int
+	if err := json.Unmarshal([]byte(lottery.WinningNumbers), &winningNumbers); err != nil {
+		s.log.Named("GetLotteryResult").Error("Unmarshal winning numbers", zap.Error(err))
+		return nil, errors.New("failed to load lottery result")
+	}
+
+	tickets, err := s.eventRepo.GetLotteryWinners(lotteryId)
+	if err != nil {
+		s.log.Named("GetLotteryResult").Error("Get lottery winners", zap.Error(err))
+		return nil, err
+	}
+
+	winners := make([]model.LotteryWinnerDto, len(tickets))
+	for i, ticket := range tickets {
+		winners[i] = model.LotteryWinnerDto{
+			Rank:     ticket.Rank,
+			Number:   ticket.Number,
+			UserId:   ticket.UserId,
+			Name:     ticket.User.Name,
+			NickName: ticket.User.NickName,
+			Prize:    ticket.Prize,
+		}
+	}
+
+	return &model.LotteryResultDto{
+		Lottery:        *lotteryToDto(lottery),
+		ClientSeed:     LotteryClientSeed(lottery.Id, lottery.TicketCount),
+		WinningNumbers: winningNumbers,
+		Winners:        winners,
+	}, nil
+}
+
+// RunLotteryDraws draws lotteries once their draw time has passed until ctx is cancelled
+func (s *eventService) RunLotteryDraws(ctx context.Context) {
+	ticker := time.NewTicker(lotteryDrawInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.drawDueLotteries()
+		}
+	}
+}
+
+func (s *eventService) drawDueLotteries() {
+	lotteryIds, err := s.eventRepo.GetDueLotteryIds(time.Now())
+	if err != nil {
+		s.log.Named("drawDueLotteries").Error("Get due lotteries", zap.Error(err))
+		return
+	}
+
+	for _, lotteryId := range lotteryIds {
+		lottery, err := s.eventRepo.DrawLottery(lotteryId, time.Now())
+		if err != nil {
+			s.log.Named("drawDueLotteries").Error("Draw lottery", zap.Error(err), zap.String("lottery_id", lotteryId))
+			continue
+		}
+		if lottery != nil {
+			s.log.Named("drawDueLotteries").Info("Drew lottery",
+				zap.String("lottery_id", lotteryId),
+				zap.Int("tickets", lottery.TicketCount),
+				zap.String("winning_numbers", lottery.WinningNumbers))
+		}
+	}
+}
+
+func lotteryToDto(lottery *model.Lottery) *model.LotteryDto {
+	var prizes []model.LotteryPrizeTierDto
+	_ = json.Unmarshal([]byte(lottery.Prizes), &prizes)
+
+	lotteryDto := &model.LotteryDto{
+		Id:                lottery.Id,
+		Title:             lottery.Title,
+		TicketPrice:       lottery.TicketPrice,
+		PotRate:           lottery.PotRate,
+		Pot:               LotteryPot(lottery.TicketPrice, lottery.TicketCount, lottery.PotRate),
+		MaxTicketsPerUser: lottery.MaxTicketsPerUser,
+		Prizes:            prizes,
+		TicketCount:       lottery.TicketCount,
+		Status:            lottery.Status,
+		ServerSeedHash:    lottery.ServerSeedHash,
+		SalesStartAt:      lottery.SalesStartAt,
+		SalesEndAt:        lottery.SalesEndAt,
+		DrawAt:            lottery.DrawAt,
+		DrawnAt:           lottery.DrawnAt,
+	}
+	if lottery.Status == LotteryDrawn {
+		lotteryDto.ServerSeed = lottery.ServerSeed
+	}
+	return lotteryDto
+}
+
+func ticketsToDto(tickets []model.LotteryTicket) []model.LotteryTicketDto {
+	ticketDtos := make([]model.LotteryTicketDto, len(tickets))
+	for i, ticket := range tickets {
+		ticketDtos[i] = model.LotteryTicketDto{
+			LotteryId: ticket.LotteryId,
+			Number:    ticket.Number,
+			Rank:      ticket.Rank,
+			Prize:     ticket.Prize,
+			CreatedAt: ticket.CreatedAt,
+		}
+	}
+	return ticketDtos
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+)
+
+// streakMultipliers scale the daily reward by consecutive claim days, the last one applies from then on
+var streakMultipliers = []float64{1.0, 1.1, 1.2, 1.3, 1.5, 1.75, 2.0}
+
+// StreakMultiplier returns the daily reward multiplier for the given day of a streak
+func StreakMultiplier(streak int) float64 {
+	if streak < 1 {
+		return streakMultipliers[0]
+	}
+	if streak > len(streakMultipliers) {
+		return streakMultipliers[len(streakMultipliers)-1]
+	}
+	return streakMultipliers[streak-1]
+}
+
+// Lottery status
+const (
+	LotteryOpen  = "open"
+	LotteryDrawn = "drawn"
+)
+
+// LotteryClientSeed combines the lottery id with its final ticket count, which nobody knows
+// when the server seed hash is published
+func LotteryClientSeed(lotteryId string, ticketCount int) string {
+	return fmt.Sprintf("%s:%d", lotteryId, ticketCount)
+}
+
+// DrawWinningNumbers picks up to count distinct ticket numbers from 1 to ticketCount, in rank order.
+// Each roll picks from the numbers still left, so anyone can replay the draw from the seeds.
+func DrawWinningNumbers(serverSeed string, clientSeed string, ticketCount int, count int) []int {
+	if count > ticketCount {
+		count = ticketCount
+	}
+	if count <= 0 {
+		return []int{}
+	}
+
+	remaining := make([]int, ticketCount)
+	for i := range remaining {
+		remaining[i] = i + 1
+	}
+
+	winners := make([]int, 0, count)
+	for _, f := range utils.FairFloats(serverSeed, clientSeed, 0, count) {
+		idx := int(f * float64(len(remaining)))
+		winners = append(winners, remaining[idx])
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+	}
+	return winners
+}
+
+// LotteryPot returns the coins shared by pot prizes
+func LotteryPot(ticketPrice float64, ticketCount int, potRate float64) float64 {
+	return roundToTwoDecimals(ticketPrice * float64(ticketCount) * potRate)
+}
+
+// LotteryPrize returns what a prize tier pays out of the pot
+func LotteryPrize(pot float64, tier model.LotteryPrizeTierDto) float64 {
+	return math.Floor((pot*tier.PotShare+tier.FixedAmount)*100) / 100
+}
+
+// ValidateLotteryPrizes checks that the pot shares do not pay out more than the pot
+func ValidateLotteryPrizes(prizes []model.LotteryPrizeTierDto) error {
+	if len(prizes) == 0 {
+		return errors.New("at least one prize is required")
+	}
+
+	totalShare := 0.0
+	for _, tier := range prizes {
+		if tier.PotShare < 0 || tier.FixedAmount < 0 {
+			return errors.New("prize shares and amounts cannot be negative")
+		}
+		if tier.PotShare == 0 && tier.FixedAmount == 0 {
+			return errors.New("every prize needs a pot share or a fixed amount")
+		}
+		totalShare += tier.PotShare
+	}
+	if totalShare > 1+1e-9 {
+		return errors.New("prize pot shares cannot add up to more than 1")
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package leaderboard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/cache"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// billResultsQuery resolves every bill of the active season to its payout the same way match settlement pays it,
+// a bill is settled once all its lines are paid and lost when any line backed the losing color
+var billResultsQuery = `
+	SELECT bill_heads.id, bill_heads.user_id, bill_heads.total, bill_heads.created_at,
+		BOOL_AND(bill_lines.is_paid) AS settled,
+		CASE WHEN BOOL_OR(NOT matches.is_draw AND matches.winner_id IS NOT NULL AND matches.winner_id <> bill_lines.betting_on) THEN 0
+			ELSE ROUND((bill_heads.total * EXP(SUM(LN(CASE WHEN matches.is_draw OR matches.winner_id IS DISTINCT FROM bill_lines.betting_on THEN 1 ELSE bill_lines.rate END))))::numeric, 2)
+		END AS payout
+	FROM bill_heads
+	JOIN bill_lines ON bill_lines.bill_id = bill_heads.id
+	JOIN matches ON matches.id = bill_lines.match_id
+	WHERE ` + model.InActiveSeason("bill_heads.season_id") + `
+	GROUP BY bill_heads.id`
+
+// playerFilter limits a board to visible players, optionally of one group or color
+const playerFilter = `
+	JOIN users ON users.id = scores.user_id
+	LEFT JOIN intania_groups ON intania_groups.id = users.group_id
+	WHERE NOT users.hide_from_leaderboard
+		AND (@group_id = '' OR users.group_id = @group_id)
+		AND (@color_id = '' OR intania_groups.color_id = @color_id)`
+
+type leaderboardRepositoryImpl struct {
+	db    *gorm.DB
+	cache cache.RedisClient
+}
+
+func NewLeaderboardRepository(db *gorm.DB, cache cache.RedisClient) LeaderboardRepository {
+	return &leaderboardRepositoryImpl{db: db, cache: cache}
+}
+
+// GetScores computes every visible player's value on board for activity since the given time
+func (r *leaderboardRepositoryImpl) GetScores(board string, since *time.Time, groupId string, colorId string) ([]Score, error) {
+	var query string
+	switch board {
+	case BoardWealth:
+		query = `
+			WITH scores AS (SELECT id AS user_id, remaining_coin AS value FROM users)
+			SELECT scores.user_id, scores.value FROM scores` + playerFilter
+	case BoardNetProfit:
+		query = `
+			WITH bill_results AS (` + billResultsQuery + `),
+			profits AS (
+				SELECT user_id, payout - total AS profit FROM bill_results
+				WHERE settled AND created_at >= @since
+				UNION ALL
+				SELECT user_id, CASE WHEN status IN ('won', 'cashed_out') THEN current_payout ELSE 0 END - bet_amount FROM mine_games
+				WHERE status IN ('won', 'cashed_out', 'lost', 'expired') AND completed_at >= @since
+					AND ` + model.InActiveSeason("season_id") + `
+				UNION ALL
+				SELECT user_id, reward - spend_amount FROM slot_spins
+				WHERE created_at >= @since AND ` + model.InActiveSeason("season_id") + `
+			),
+			scores AS (SELECT user_id, SUM(profit) AS value FROM profits GROUP BY user_id)
+			SELECT scores.user_id, scores.value FROM scores` + playerFilter
+	case BoardWinRate:
+		query = `
+			WITH bill_results AS (` + billResultsQuery + `),
+			scores AS (
+				SELECT user_id, ROUND(COUNT(*) FILTER (WHERE payout > total) * 100.0 / COUNT(*), 2) AS value FROM bill_results
+				WHERE settled AND created_at >= @since
+				GROUP BY user_id
+				HAVING COUNT(*) >= @min_bills
+			)
+			SELECT scores.user_id, scores.value FROM scores` + playerFilter
+	default:
+		return nil, fmt.Errorf("unknown leaderboard %q", board)
+	}
+
+	// All time boards count everything since the zero time
+	var from time.Time
+	if since != nil {
+		from = *since
+	}
+
+	var scores []Score
+	err := r.db.Raw(query, map[string]interface{}{
+		"since":     from,
+		"group_id":  groupId,
+		"color_id":  colorId,
+		"min_bills": MinSettledBills,
+	}).Scan(&scores).Error
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+func (r *leaderboardRepositoryImpl) FindVisibleUsers(userIds []string) ([]model.User, error) {
+	var users []model.User
+	err := r.db.Preload("Group").
+		Where("id IN ? AND NOT hide_from_leaderboard", userIds).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *leaderboardRepositoryImpl) GroupExists(groupId string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.IntaniaGroup{}).Where("id = ?", groupId).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *leaderboardRepositoryImpl) ColorExists(colorId string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.Color{}).Where("id = ?", colorId).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *leaderboardRepositoryImpl) GetRanking(key string, start int64, stop int64) ([]redis.Z, error) {
+	return r.cache.GetSortedSetRange(key, start, stop)
+}
+
+func (r *leaderboardRepositoryImpl) GetRank(key string, userId string) (int64, float64, error) {
+	return r.cache.GetSortedSetRank(key, userId)
+}
+
+// SetRanking caches scores as a sorted set, an empty ranking is cached as just the sentinel member
+// since Redis does not keep empty sorted sets
+func (r *leaderboardRepositoryImpl) SetRanking(key string, scores []Score, ttl int) error {
+	members := make(map[string]float64, len(scores))
+	for _, score := range scores {
+		members[score.UserId] = score.Value
+	}
+	if len(members) == 0 {
+		members[EmptyRankingMember] = 0
+	}
+	return r.cache.SetSortedSet(key, members, ttl)
+}
@@ -0,0 +1,55 @@
+package leaderboard
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LeaderboardHttpHandler struct {
+	service LeaderboardService
+}
+
+func NewLeaderboardHttpHandler(service LeaderboardService) *LeaderboardHttpHandler {
+	return &LeaderboardHttpHandler{service: service}
+}
+
+func (h *LeaderboardHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/leaderboards", mid.AuthMiddleware)
+
+	router.Get("/players", h.GetPlayerLeaderboard)
+}
+
+// @Summary Get player leaderboard
+// @Description Rank players by current coins (wealth), net profit from bills, mines and slots over a period, or settled bill win rate (at least 5 settled bills). Players who hide themselves from leaderboards are left out.
+// @Tags Leaderboard
+// @Produce json
+// @Param type query string true "Leaderboard type" Enums(wealth, net_profit, win_rate)
+// @Param period query string false "Period, ignored for wealth" Enums(day, week, month, all) default(all)
+// @Param group query string false "Intania group ID"
+// @Param color query string false "Color ID"
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} model.PlayerLeaderboardDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /leaderboards/players [get]
+// @Security BearerAuth
+func (h *LeaderboardHttpHandler) GetPlayerLeaderboard(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	leaderboard, err := h.service.GetPlayerLeaderboard(profile.Id, c.Query("type"), c.Query("period", "all"), c.Query("group"), c.Query("color"), limit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(leaderboard)
+}
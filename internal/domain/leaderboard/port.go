@@ -0,0 +1,28 @@
+package leaderboard
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// Score is a user's value on a leaderboard before ranking
+type Score struct {
+	UserId string
+	Value  float64
+}
+
+type LeaderboardService interface {
+	GetPlayerLeaderboard(userId string, board string, period string, groupId string, colorId string, limit int) (*model.PlayerLeaderboardDto, error)
+}
+
+type LeaderboardRepository interface {
+	GetScores(board string, since *time.Time, groupId string, colorId string) ([]Score, error)
+	FindVisibleUsers(userIds []string) ([]model.User, error)
+	GroupExists(groupId string) (bool, error)
+	ColorExists(colorId string) (bool, error)
+	GetRanking(key string, start int64, stop int64) ([]redis.Z, error)
+	GetRank(key string, userId string) (int64, float64, error)
+	SetRanking(key string, scores []Score, ttl int) error
+}
@@ -0,0 +1,218 @@
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakemine"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type leaderboardServiceImpl struct {
+	repo LeaderboardRepository
+	cfg  config.Config
+	log  *zap.Logger
+}
+
+func NewLeaderboardService(repo LeaderboardRepository, cfg config.Config, log *zap.Logger) LeaderboardService {
+	return &leaderboardServiceImpl{
+		repo: repo,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// GetPlayerLeaderboard returns the top players of a board with the caller's own rank,
+// the full ranking is kept in a Redis sorted set until the configured TTL expires
+func (s *leaderboardServiceImpl) GetPlayerLeaderboard(userId string, board string, period string, groupId string, colorId string, limit int) (*model.PlayerLeaderboardDto, error) {
+	if !ValidateBoard(board) {
+		return nil, errors.New("invalid leaderboard type. must be 'wealth', 'net_profit' or 'win_rate'")
+	}
+	if period == "" || board == BoardWealth {
+		// Wealth is the current balance so it has no window
+		period = "all"
+	}
+
+	since, err := stakemine.GetPeriodStart(period, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Only known filters reach the cache key, so the number of cached rankings stays bounded
+	if err := s.validateFilters(groupId, colorId); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("leaderboard/%v/%v/%v/%v", board, period, groupId, colorId)
+
+	top, me, err := s.ranking(key, userId, limit)
+	if err != nil {
+		if err != redis.Nil {
+			s.log.Named("GetPlayerLeaderboard").Warn("Failed to read leaderboard cache", zap.Error(err))
+		}
+
+		scores, err := s.repo.GetScores(board, since, groupId, colorId)
+		if err != nil {
+			s.log.Named("GetPlayerLeaderboard").Error("Failed to get scores", zap.Error(err))
+			return nil, errors.New("failed to get leaderboard")
+		}
+		SortScores(scores)
+
+		if err := s.repo.SetRanking(key, scores, s.cfg.GetGame().LeaderboardTTL); err != nil {
+			s.log.Named("GetPlayerLeaderboard").Warn("Failed to cache leaderboard", zap.Error(err))
+		}
+
+		top, me = rankScores(scores, userId, limit)
+	}
+
+	entries, meEntry, err := s.toEntries(top, me)
+	if err != nil {
+		s.log.Named("GetPlayerLeaderboard").Error("Failed to find users", zap.Error(err))
+		return nil, errors.New("failed to get leaderboard")
+	}
+
+	return &model.PlayerLeaderboardDto{
+		Type:    board,
+		Period:  period,
+		GroupId: groupId,
+		ColorId: colorId,
+		Entries: entries,
+		Me:      meEntry,
+	}, nil
+}
+
+func (s *leaderboardServiceImpl) validateFilters(groupId string, colorId string) error {
+	if groupId != "" {
+		exists, err := s.repo.GroupExists(groupId)
+		if err != nil {
+			s.log.Named("validateFilters").Error("Failed to find group", zap.Error(err))
+			return errors.New("failed to get leaderboard")
+		}
+		if !exists {
+			return errors.New("group not found")
+		}
+	}
+
+	if colorId != "" {
+		exists, err := s.repo.ColorExists(colorId)
+		if err != nil {
+			s.log.Named("validateFilters").Error("Failed to find color", zap.Error(err))
+			return errors.New("failed to get leaderboard")
+		}
+		if !exists {
+			return errors.New("color not found")
+		}
+	}
+
+	return nil
+}
+
+// ranking reads the top of a cached ranking and the user's place in it, redis.Nil when not cached
+func (s *leaderboardServiceImpl) ranking(key string, userId string, limit int) ([]model.PlayerLeaderboardEntryDto, *model.PlayerLeaderboardEntryDto, error) {
+	zs, err := s.repo.GetRanking(key, 0, int64(limit-1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	top := make([]model.PlayerLeaderboardEntryDto, 0, len(zs))
+	for i, z := range zs {
+		member := fmt.Sprint(z.Member)
+		if member == EmptyRankingMember {
+			continue
+		}
+		top = append(top, model.PlayerLeaderboardEntryDto{
+			Rank:   i + 1,
+			UserId: member,
+			Value:  z.Score,
+		})
+	}
+
+	rank, score, err := s.repo.GetRank(key, userId)
+	if err == redis.Nil {
+		return top, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return top, &model.PlayerLeaderboardEntryDto{
+		Rank:   int(rank) + 1,
+		UserId: userId,
+		Value:  score,
+	}, nil
+}
+
+// toEntries fills in the player details, players who hid themselves since the ranking was cached are dropped
+func (s *leaderboardServiceImpl) toEntries(top []model.PlayerLeaderboardEntryDto, me *model.PlayerLeaderboardEntryDto) ([]model.PlayerLeaderboardEntryDto, *model.PlayerLeaderboardEntryDto, error) {
+	userIds := make([]string, 0, len(top)+1)
+	for _, entry := range top {
+		userIds = append(userIds, entry.UserId)
+	}
+	if me != nil {
+		userIds = append(userIds, me.UserId)
+	}
+	if len(userIds) == 0 {
+		return []model.PlayerLeaderboardEntryDto{}, nil, nil
+	}
+
+	users, err := s.repo.FindVisibleUsers(userIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	usersById := make(map[string]model.User, len(users))
+	for _, user := range users {
+		usersById[user.Id] = user
+	}
+
+	entries := make([]model.PlayerLeaderboardEntryDto, 0, len(top))
+	for _, entry := range top {
+		if user, ok := usersById[entry.UserId]; ok {
+			entries = append(entries, withUser(entry, &user))
+		}
+	}
+
+	var meEntry *model.PlayerLeaderboardEntryDto
+	if me != nil {
+		if user, ok := usersById[me.UserId]; ok {
+			entry := withUser(*me, &user)
+			meEntry = &entry
+		}
+	}
+
+	return entries, meEntry, nil
+}
+
+func rankScores(scores []Score, userId string, limit int) ([]model.PlayerLeaderboardEntryDto, *model.PlayerLeaderboardEntryDto) {
+	top := make([]model.PlayerLeaderboardEntryDto, 0, limit)
+	var me *model.PlayerLeaderboardEntryDto
+
+	for i, score := range scores {
+		entry := model.PlayerLeaderboardEntryDto{
+			Rank:   i + 1,
+			UserId: score.UserId,
+			Value:  score.Value,
+		}
+		if i < limit {
+			top = append(top, entry)
+		}
+		if score.UserId == userId {
+			me = &entry
+		}
+	}
+
+	return top, me
+}
+
+func withUser(entry model.PlayerLeaderboardEntryDto, user *model.User) model.PlayerLeaderboardEntryDto {
+	entry.Name = user.Name
+	entry.NickName = user.NickName
+	entry.GroupId = user.GroupId
+	if user.GroupId != nil {
+		entry.ColorId = &user.Group.ColorId
+	}
+	return entry
+}
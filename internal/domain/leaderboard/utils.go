@@ -0,0 +1,39 @@
+package leaderboard
+
+import (
+	"sort"
+)
+
+// Player leaderboards
+const (
+	BoardWealth    = "wealth"
+	BoardNetProfit = "net_profit"
+	BoardWinRate   = "win_rate"
+)
+
+// EmptyRankingMember marks a cached ranking that has no players
+const EmptyRankingMember = "-"
+
+// MinSettledBills is how many settled bills a player needs before appearing on the win rate board
+const MinSettledBills = 5
+
+// ValidateBoard checks if the leaderboard type is valid
+func ValidateBoard(board string) bool {
+	switch board {
+	case BoardWealth, BoardNetProfit, BoardWinRate:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortScores orders scores the way a Redis sorted set ranks them, highest value first
+// and ties broken by the user id in reverse order
+func SortScores(scores []Score) {
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Value != scores[j].Value {
+			return scores[i].Value > scores[j].Value
+		}
+		return scores[i].UserId > scores[j].UserId
+	})
+}
@@ -30,7 +30,7 @@ func (r *matchRepositoryImpl) GetById(matchId string) (*model.Match, error) {
 
 func (r *matchRepositoryImpl) GetAll(filter *model.MatchFilter) ([]*model.Match, error) {
 	var matches []*model.Match
-	db := r.db
+	db := r.db.Where(model.InActiveSeason("season_id"))
 
 	if filter != nil {
 		if filter.TypeId != "" {
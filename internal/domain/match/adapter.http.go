@@ -21,13 +21,12 @@ func (h *MatchHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.M
 	router.Get("/:id", h.GetMatch)
 	router.Get("/current/time", h.GetTime)
 
-	adminRouter := router.Group("", mid.AdminMiddleware)
-	adminRouter.Post("/", h.CreateMatch)
-	adminRouter.Put("/:id", h.UpdateMatch)
-	adminRouter.Patch("/:id/winner/:winner_id", h.UpdateMatchWinner)
-	adminRouter.Patch("/:id/score", h.UpdateMatchScore)
-	adminRouter.Patch("/:id/draw", h.UpdateMatchDraw)
-	adminRouter.Delete("/:id", h.DeleteMatch)
+	router.Post("/", mid.RequirePermission(model.PermMatchWrite), h.CreateMatch)
+	router.Put("/:id", mid.RequirePermission(model.PermMatchWrite), h.UpdateMatch)
+	router.Patch("/:id/winner/:winner_id", mid.RequirePermission(model.PermMatchSettle), h.UpdateMatchWinner)
+	router.Patch("/:id/score", mid.RequirePermission(model.PermMatchSettle), h.UpdateMatchScore)
+	router.Patch("/:id/draw", mid.RequirePermission(model.PermMatchSettle), h.UpdateMatchDraw)
+	router.Delete("/:id", mid.RequirePermission(model.PermMatchWrite), h.DeleteMatch)
 }
 
 // CreateMatch @Summary      Create a new match
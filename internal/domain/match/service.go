@@ -4,18 +4,22 @@ import (
 	"errors"
 	"time"
 
+	"github.com/esc-chula/intania-888-backend/internal/domain/quest"
+	"github.com/esc-chula/intania-888-backend/internal/domain/teampool"
 	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type matchServiceImpl struct {
-	repo MatchRepository
-	log  *zap.Logger
+	repo         MatchRepository
+	questTracker quest.QuestTracker
+	poolSettler  teampool.MatchSettler
+	log          *zap.Logger
 }
 
-func NewMatchService(repo MatchRepository, log *zap.Logger) MatchService {
-	return &matchServiceImpl{repo, log}
+func NewMatchService(repo MatchRepository, questTracker quest.QuestTracker, poolSettler teampool.MatchSettler, log *zap.Logger) MatchService {
+	return &matchServiceImpl{repo, questTracker, poolSettler, log}
 }
 
 func (s *matchServiceImpl) CreateMatch(matchDto *model.MatchDto) error {
@@ -162,6 +166,12 @@ func (s *matchServiceImpl) UpdateMatchWinner(matchId string, winnerId string) er
 		return err
 	}
 
+	// Pay the team pools backing the winner
+	if err := s.poolSettler.SettleMatch(matchId); err != nil {
+		s.log.Error("Failed to settle team pools for match", zap.Error(err))
+		return err
+	}
+
 	s.log.Info("Updated match winner and processed payouts", zap.String("match_id", matchId))
 	return nil
 }
@@ -236,6 +246,13 @@ func (s *matchServiceImpl) processPayoutsForMatch(matchId string) error {
 			}
 
 			s.log.Info("Processed payout for user", zap.String("user_id", billHead.UserId))
+
+			if payout > billHead.Total {
+				s.questTracker.Track(billHead.UserId, &quest.Event{
+					Type:  quest.EventBillWon,
+					Lines: len(billHead.Lines),
+				})
+			}
 		}
 	}
 
@@ -279,6 +296,13 @@ func (s *matchServiceImpl) UpdateMatchDraw(matchId string) error {
 		return err
 	}
 
+	// Refund the team pools of both colors
+	err = s.poolSettler.SettleMatch(matchId)
+	if err != nil {
+		s.log.Error("Failed to settle team pools for draw match", zap.Error(err))
+		return err
+	}
+
 	s.log.Info("Updated match as draw and processed payouts", zap.String("match_id", matchId))
 	return nil
 }
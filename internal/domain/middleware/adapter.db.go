@@ -20,3 +20,30 @@ func (r *middlewareRepositoryImpl) GetById(id string) (*model.User, error) {
 	}
 	return &user, nil
 }
+
+func (r *middlewareRepositoryImpl) GetRolePermissions(roleId string) ([]string, error) {
+	var permissions []string
+	err := r.db.Table("role_permissions").
+		Where("role_id = ?", roleId).
+		Pluck("permission_id", &permissions).Error
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *middlewareRepositoryImpl) HasActiveBan(userId string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.UserBan{}).Where("user_id = ? AND "+model.ActiveBan, userId).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *middlewareRepositoryImpl) HasSignupBan(userId string, email string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.SignupBan{}).Where("email = ? OR user_id = ?", email, userId).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
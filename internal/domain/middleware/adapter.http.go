@@ -5,7 +5,6 @@ import (
 	"slices"
 	"strings"
 
-	"github.com/esc-chula/intania-888-backend/internal/model"
 	"github.com/esc-chula/intania-888-backend/utils"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -59,7 +58,7 @@ func (h *MiddlewareHttpHandler) AuthMiddleware(c *fiber.Ctx) error {
 	}
 
 	// Verify the token
-	userId, err := h.service.VerifyToken(token[1])
+	claim, err := h.service.VerifyToken(token[1])
 	if err != nil {
 		h.log.Named("AuthMiddleware").Error("Token verification failed", zap.Error(err))
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -68,7 +67,7 @@ func (h *MiddlewareHttpHandler) AuthMiddleware(c *fiber.Ctx) error {
 	}
 
 	// Get the user profile
-	userDto, err := h.service.GetMe(*userId)
+	userDto, err := h.service.GetMe(claim.UserId)
 	if err != nil {
 		h.log.Named("AuthMiddleware").Error("User not found", zap.Error(err))
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -76,7 +75,8 @@ func (h *MiddlewareHttpHandler) AuthMiddleware(c *fiber.Ctx) error {
 		})
 	}
 
-	if isInBlacklists(userDto) {
+	if banned, err := h.service.IsBanned(userDto.Id); err != nil || banned {
+		h.log.Named("AuthMiddleware").Warn("Banned user blocked", zap.String("user_id", userDto.Id), zap.Error(err))
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "missing authorization header",
 		})
@@ -84,6 +84,7 @@ func (h *MiddlewareHttpHandler) AuthMiddleware(c *fiber.Ctx) error {
 
 	// Store user in context for downstream handlers
 	c.Locals("user", userDto)
+	c.Locals("session_id", claim.SessionId)
 
 	return c.Next()
 }
@@ -111,66 +112,37 @@ func isBrowserHeadersValid(c *fiber.Ctx) bool {
 	return acceptLanguage != "" && acceptEncoding != "" && secFetchMode != ""
 }
 
-func (h *MiddlewareHttpHandler) AdminMiddleware(c *fiber.Ctx) error {
-	user := utils.GetUserProfileFromCtx(c)
-	if user == nil {
-		h.log.Named("AdminMiddleware").Error("User not found in context")
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
-	}
-
-	// Check if user is hardcoded admin or has ADMIN role
-	authorizedAdmins := []string{
-		"6633165121@student.chula.ac.th",
-		"6738086221@student.chula.ac.th",
-		"6633149121@student.chula.ac.th",
-	}
-	isAuthorizedAdmin := false
-	for _, adminEmail := range authorizedAdmins {
-		if user.Email == adminEmail {
-			isAuthorizedAdmin = true
-			break
+// RequirePermission only lets users through whose role grants every listed permission
+func (h *MiddlewareHttpHandler) RequirePermission(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := utils.GetUserProfileFromCtx(c)
+		if user == nil {
+			h.log.Named("RequirePermission").Error("User not found in context")
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "unauthorized",
+			})
 		}
-	}
 
-	if !isAuthorizedAdmin && user.RoleId != "ADMIN" {
-		h.log.Named("AdminMiddleware").Warn("Non-admin attempted admin action",
-			zap.String("user_id", user.Id),
-			zap.String("role", user.RoleId),
-			zap.String("email", user.Email),
-			zap.String("endpoint", c.Path()))
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error": "admin access required",
-		})
-	}
-
-	return c.Next()
-}
-
-func isInBlacklists(user *model.UserDto) bool {
-	blacklistEmail := []string{
-		"6530162621@student.chula.ac.th",
-		"6633129621@student.chula.ac.th",
-		"6733023821@student.chula.ac.th",
-		"6630054621@student.chula.ac.th",
-		"6538004621@student.chula.ac.th",
-		"6733291621@student.chula.ac.th",
-		"6430039021@student.chula.ac.th",
-	}
-
-	blacklistId := []string{
-		"115982048644097094953",
-		"101935624102444830754",
-	}
+		granted, err := h.service.GetRolePermissions(user.RoleId)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to check permissions",
+			})
+		}
 
-	if found := slices.Contains(blacklistEmail, user.Email); found {
-		return true
-	}
+		for _, permission := range permissions {
+			if !slices.Contains(granted, permission) {
+				h.log.Named("RequirePermission").Warn("Missing permission",
+					zap.String("user_id", user.Id),
+					zap.String("role", user.RoleId),
+					zap.String("permission", permission),
+					zap.String("endpoint", c.Path()))
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": "permission required: " + permission,
+				})
+			}
+		}
 
-	if found := slices.Contains(blacklistId, user.Id); found {
-		return true
+		return c.Next()
 	}
-
-	return false
 }
@@ -28,7 +28,7 @@ func (h *MiddlewareHttpHandler) ExternalAPIMiddleware(c *fiber.Ctx) error {
 	}
 
 	// Verify the token
-	userId, err := h.service.VerifyToken(token[1])
+	claim, err := h.service.VerifyToken(token[1])
 	if err != nil {
 		h.log.Named("ExternalAPIMiddleware").Error("Token verification failed", zap.Error(err))
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -37,7 +37,7 @@ func (h *MiddlewareHttpHandler) ExternalAPIMiddleware(c *fiber.Ctx) error {
 	}
 
 	// Get the user profile
-	userDto, err := h.service.GetMe(*userId)
+	userDto, err := h.service.GetMe(claim.UserId)
 	if err != nil {
 		h.log.Named("ExternalAPIMiddleware").Error("User not found", zap.Error(err))
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -45,11 +45,12 @@ func (h *MiddlewareHttpHandler) ExternalAPIMiddleware(c *fiber.Ctx) error {
 		})
 	}
 
-	// Check blacklist (MUST enforce for security)
-	if isInBlacklists(userDto) {
-		h.log.Named("ExternalAPIMiddleware").Warn("Blacklisted user attempted external API access",
+	// Check bans (MUST enforce for security)
+	if banned, err := h.service.IsBanned(userDto.Id); err != nil || banned {
+		h.log.Named("ExternalAPIMiddleware").Warn("Banned user attempted external API access",
 			zap.String("userId", userDto.Id),
-			zap.String("email", userDto.Email))
+			zap.String("email", userDto.Email),
+			zap.Error(err))
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "unauthorized",
 		})
@@ -57,6 +58,7 @@ func (h *MiddlewareHttpHandler) ExternalAPIMiddleware(c *fiber.Ctx) error {
 
 	// Store user in context for downstream handlers
 	c.Locals("user", userDto)
+	c.Locals("session_id", claim.SessionId)
 
 	return c.Next()
 }
@@ -3,10 +3,16 @@ package middleware
 import "github.com/esc-chula/intania-888-backend/internal/model"
 
 type MiddlewareService interface {
-	VerifyToken(token string) (*string, error)
+	VerifyToken(token string) (*model.AccessTokenClaimDto, error)
 	GetMe(userId string) (*model.UserDto, error)
+	IsBanned(userId string) (bool, error)
+	IsSignupBanned(userId string, email string) (bool, error)
+	GetRolePermissions(roleId string) ([]string, error)
 }
 
 type MiddlewareRepository interface {
 	GetById(id string) (*model.User, error)
+	HasActiveBan(userId string) (bool, error)
+	HasSignupBan(userId string, email string) (bool, error)
+	GetRolePermissions(roleId string) ([]string, error)
 }
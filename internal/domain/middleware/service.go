@@ -10,6 +10,12 @@ import (
 	"go.uber.org/zap"
 )
 
+// banCacheTtl bounds how long an expired ban keeps blocking, bans and unbans clear the cache directly
+const banCacheTtl = 300
+
+// rolePermissionsCacheTtl bounds how long a permission change to a role takes to apply
+const rolePermissionsCacheTtl = 60
+
 type middlewareServiceImpl struct {
 	repo  MiddlewareRepository
 	cache *cache.RedisClient
@@ -26,7 +32,7 @@ func NewMiddlewareService(repo MiddlewareRepository, cache *cache.RedisClient, l
 	}
 }
 
-func (u *middlewareServiceImpl) VerifyToken(token string) (*string, error) {
+func (u *middlewareServiceImpl) VerifyToken(token string) (*model.AccessTokenClaimDto, error) {
 	claim, err := utils.JwtParseToken(token, u.cfg.GetJwt().AccessTokenSecret)
 	if err != nil {
 		u.log.Named("VerifyToken").Error("Parsing token: ", zap.Error(err))
@@ -40,8 +46,15 @@ func (u *middlewareServiceImpl) VerifyToken(token string) (*string, error) {
 		return nil, errors.New("user id not found in token")
 	}
 
+	// get sessionId in token, tokens issued before sessions existed have none
+	sessionId, ok := claim["sid"].(string)
+	if !ok || sessionId == "" {
+		u.log.Named("VerifyToken").Error("Getting session_id from claim: ", zap.Error(errors.New("error while getting session_id from claim")))
+		return nil, errors.New("session id not found in token")
+	}
+
 	var credential model.CredentialDto
-	err = u.cache.GetValue(utils.ToAccessCacheKey(userId), &credential)
+	err = u.cache.GetValue(utils.ToAccessCacheKey(userId, sessionId), &credential)
 	if err != nil {
 		u.log.Named("ValidateToken").Error("GetValue: ", zap.Error(err))
 		return nil, err
@@ -51,7 +64,7 @@ func (u *middlewareServiceImpl) VerifyToken(token string) (*string, error) {
 	}
 
 	u.log.Named("VerifyToken").Info("Success: ", zap.String("user_id", userId))
-	return &userId, nil
+	return &model.AccessTokenClaimDto{UserId: userId, SessionId: sessionId}, nil
 }
 
 func (s *middlewareServiceImpl) GetMe(userId string) (*model.UserDto, error) {
@@ -71,3 +84,51 @@ func (s *middlewareServiceImpl) GetMe(userId string) (*model.UserDto, error) {
 		NickName:      user.NickName,
 	}, nil
 }
+
+// IsBanned reports whether the user has an active ban, cached for the auth hot path
+func (s *middlewareServiceImpl) IsBanned(userId string) (bool, error) {
+	var banCache model.BanCacheDto
+	if err := s.cache.GetValue(utils.ToBanCacheKey(userId), &banCache); err == nil {
+		return banCache.Banned, nil
+	}
+
+	banned, err := s.repo.HasActiveBan(userId)
+	if err != nil {
+		s.log.Named("IsBanned").Error("HasActiveBan: ", zap.Error(err))
+		return false, err
+	}
+
+	if err := s.cache.SetValue(utils.ToBanCacheKey(userId), model.BanCacheDto{Banned: banned}, banCacheTtl); err != nil {
+		s.log.Named("IsBanned").Warn("SetValue: ", zap.Error(err))
+	}
+	return banned, nil
+}
+
+// IsSignupBanned reports whether the account or email is kept from signing up, only checked on sign up so it is not cached
+func (s *middlewareServiceImpl) IsSignupBanned(userId string, email string) (bool, error) {
+	banned, err := s.repo.HasSignupBan(userId, email)
+	if err != nil {
+		s.log.Named("IsSignupBanned").Error("HasSignupBan: ", zap.Error(err))
+		return false, err
+	}
+	return banned, nil
+}
+
+// GetRolePermissions returns the permissions granted to the role, cached since every admin request checks them
+func (s *middlewareServiceImpl) GetRolePermissions(roleId string) ([]string, error) {
+	var permissions []string
+	if err := s.cache.GetValue(utils.ToRolePermissionsCacheKey(roleId), &permissions); err == nil {
+		return permissions, nil
+	}
+
+	permissions, err := s.repo.GetRolePermissions(roleId)
+	if err != nil {
+		s.log.Named("GetRolePermissions").Error("GetRolePermissions: ", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.cache.SetValue(utils.ToRolePermissionsCacheKey(roleId), permissions, rolePermissionsCacheTtl); err != nil {
+		s.log.Named("GetRolePermissions").Warn("SetValue: ", zap.Error(err))
+	}
+	return permissions, nil
+}
@@ -0,0 +1,76 @@
+package moderation
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/cache"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"gorm.io/gorm"
+)
+
+type moderationRepositoryImpl struct {
+	db    *gorm.DB
+	cache cache.RedisClient
+}
+
+func NewModerationRepository(db *gorm.DB, cache cache.RedisClient) ModerationRepository {
+	return &moderationRepositoryImpl{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *moderationRepositoryImpl) FindUser(userId string) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("id = ?", userId).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *moderationRepositoryImpl) FindActiveBan(userId string) (*model.UserBan, error) {
+	var ban model.UserBan
+	err := r.db.Where("user_id = ? AND "+model.ActiveBan, userId).
+		Order("created_at DESC").
+		First(&ban).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func (r *moderationRepositoryImpl) FindAll(activeOnly bool, limit int, offset int) ([]model.UserBan, error) {
+	var bans []model.UserBan
+	query := r.db.Preload("User")
+	if activeOnly {
+		query = query.Where(model.ActiveBan)
+	}
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&bans).Error
+	if err != nil {
+		return nil, err
+	}
+	return bans, nil
+}
+
+func (r *moderationRepositoryImpl) Create(ban *model.UserBan) error {
+	return r.db.Create(ban).Error
+}
+
+// RevokeActive lifts every active ban of the user and returns how many were lifted
+func (r *moderationRepositoryImpl) RevokeActive(userId string, revokedBy string) (int64, error) {
+	result := r.db.Model(&model.UserBan{}).
+		Where("user_id = ? AND "+model.ActiveBan, userId).
+		Updates(map[string]interface{}{
+			"revoked_at": time.Now(),
+			"revoked_by": revokedBy,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *moderationRepositoryImpl) DeleteBanCache(userId string) error {
+	return r.cache.DeleteValue(utils.ToBanCacheKey(userId))
+}
@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ModerationHttpHandler struct {
+	service ModerationService
+}
+
+func NewModerationHttpHandler(service ModerationService) *ModerationHttpHandler {
+	return &ModerationHttpHandler{service: service}
+}
+
+func (h *ModerationHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/moderation", mid.AuthMiddleware)
+
+	adminRouter := router.Group("/admin")
+	adminRouter.Get("/bans", mid.RequirePermission(model.PermUserBan), h.GetBans)
+	adminRouter.Post("/bans", mid.RequirePermission(model.PermUserBan), h.BanUser)
+	adminRouter.Delete("/bans/:userId", mid.RequirePermission(model.PermUserBan), h.UnbanUser)
+}
+
+// @Summary Get bans
+// @Description Get user bans, newest first (admin only)
+// @Tags Moderation
+// @Produce json
+// @Param active query bool false "Only bans that are neither lifted nor expired" default(true)
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} model.UserBanDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /moderation/admin/bans [get]
+// @Security BearerAuth
+func (h *ModerationHttpHandler) GetBans(c *fiber.Ctx) error {
+	activeOnly := c.QueryBool("active", true)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "50"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	bans, err := h.service.GetBans(activeOnly, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get bans",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(bans)
+}
+
+// @Summary Ban user
+// @Description Ban a user until expires_at, or permanently when omitted, and log them out of every device (admin only)
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param request body model.BanUserRequest true "Ban"
+// @Success 201 {object} model.UserBanDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /moderation/admin/bans [post]
+// @Security BearerAuth
+func (h *ModerationHttpHandler) BanUser(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.BanUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	ban, err := h.service.BanUser(profile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(ban)
+}
+
+// @Summary Unban user
+// @Description Lift every active ban of a user (admin only)
+// @Tags Moderation
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /moderation/admin/bans/{userId} [delete]
+// @Security BearerAuth
+func (h *ModerationHttpHandler) UnbanUser(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	if err := h.service.UnbanUser(profile.Id, c.Params("userId")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
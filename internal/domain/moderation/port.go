@@ -0,0 +1,23 @@
+package moderation
+
+import "github.com/esc-chula/intania-888-backend/internal/model"
+
+type ModerationService interface {
+	BanUser(adminId string, req *model.BanUserRequest) (*model.UserBanDto, error)
+	UnbanUser(adminId string, userId string) error
+	GetBans(activeOnly bool, limit int, offset int) ([]model.UserBanDto, error)
+}
+
+type ModerationRepository interface {
+	FindUser(userId string) (*model.User, error)
+	FindActiveBan(userId string) (*model.UserBan, error)
+	FindAll(activeOnly bool, limit int, offset int) ([]model.UserBan, error)
+	Create(ban *model.UserBan) error
+	RevokeActive(userId string, revokedBy string) (int64, error)
+	DeleteBanCache(userId string) error
+}
+
+// SessionRevoker logs a banned user out of every device
+type SessionRevoker interface {
+	RevokeAllSessions(userId string) error
+}
@@ -0,0 +1,23 @@
+package moderation
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+func banToDto(ban *model.UserBan, now time.Time) model.UserBanDto {
+	return model.UserBanDto{
+		Id:        ban.Id,
+		UserId:    ban.UserId,
+		UserName:  ban.User.Name,
+		Email:     ban.User.Email,
+		Reason:    ban.Reason,
+		IssuedBy:  ban.IssuedBy,
+		ExpiresAt: ban.ExpiresAt,
+		RevokedAt: ban.RevokedAt,
+		RevokedBy: ban.RevokedBy,
+		IsActive:  ban.IsActive(now),
+		CreatedAt: ban.CreatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) Create(notification *model.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) GetByUserId(userId string, unreadOnly bool, limit int, offset int) ([]*model.Notification, error) {
+	var notifications []*model.Notification
+
+	query := r.db.Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(userId string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) MarkAsRead(userId string, notificationId string) (int64, error) {
+	result := r.db.Model(&model.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", notificationId, userId).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) MarkAllAsRead(userId string) error {
+	return r.db.Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now()).Error
+}
@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHttpHandler struct {
+	service NotificationService
+}
+
+func NewNotificationHttpHandler(service NotificationService) *NotificationHttpHandler {
+	return &NotificationHttpHandler{service: service}
+}
+
+func (h *NotificationHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/notifications", mid.AuthMiddleware)
+
+	router.Get("/", h.GetNotifications)
+	router.Patch("/read", h.MarkAllAsRead)
+	router.Patch("/:id/read", h.MarkAsRead)
+}
+
+// @Summary Get notifications
+// @Description Get the user's notifications, newest first, with the unread count
+// @Tags Notification
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} model.NotificationListDto
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /notifications [get]
+// @Security BearerAuth
+func (h *NotificationHttpHandler) GetNotifications(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	unreadOnly := c.QueryBool("unread", false)
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, err := h.service.GetNotifications(profile.Id, unreadOnly, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get notifications"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(notifications)
+}
+
+// @Summary Mark a notification as read
+// @Description Mark one of the user's notifications as read
+// @Tags Notification
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} map[string]string "marked as read"
+// @Failure 404 {object} map[string]string "notification not found"
+// @Router /notifications/{id}/read [patch]
+// @Security BearerAuth
+func (h *NotificationHttpHandler) MarkAsRead(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	if err := h.service.MarkAsRead(profile.Id, c.Params("id")); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "marked as read"})
+}
+
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of the user as read
+// @Tags Notification
+// @Produce json
+// @Success 200 {object} map[string]string "marked all as read"
+// @Failure 500 {object} map[string]string "internal server error"
+// @Router /notifications/read [patch]
+// @Security BearerAuth
+func (h *NotificationHttpHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	if err := h.service.MarkAllAsRead(profile.Id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to mark notifications as read"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "marked all as read"})
+}
@@ -0,0 +1,18 @@
+package notification
+
+import "github.com/esc-chula/intania-888-backend/internal/model"
+
+type NotificationRepository interface {
+	Create(notification *model.Notification) error
+	GetByUserId(userId string, unreadOnly bool, limit int, offset int) ([]*model.Notification, error)
+	CountUnread(userId string) (int64, error)
+	MarkAsRead(userId string, notificationId string) (int64, error)
+	MarkAllAsRead(userId string) error
+}
+
+type NotificationService interface {
+	Notify(userId string, kind string, title string, message string) error
+	GetNotifications(userId string, unreadOnly bool, limit int, offset int) (*model.NotificationListDto, error)
+	MarkAsRead(userId string, notificationId string) error
+	MarkAllAsRead(userId string) error
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type notificationService struct {
+	notificationRepo NotificationRepository
+	log              *zap.Logger
+}
+
+func NewNotificationService(notificationRepo NotificationRepository, log *zap.Logger) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		log:              log,
+	}
+}
+
+func (s *notificationService) Notify(userId string, kind string, title string, message string) error {
+	notification := &model.Notification{
+		Id:        uuid.NewString(),
+		UserId:    userId,
+		Type:      kind,
+		Title:     title,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.notificationRepo.Create(notification); err != nil {
+		s.log.Named("Notify").Error("Failed to create notification", zap.Error(err), zap.String("user_id", userId))
+		return err
+	}
+
+	return nil
+}
+
+func (s *notificationService) GetNotifications(userId string, unreadOnly bool, limit int, offset int) (*model.NotificationListDto, error) {
+	notifications, err := s.notificationRepo.GetByUserId(userId, unreadOnly, limit, offset)
+	if err != nil {
+		s.log.Named("GetNotifications").Error("Failed to get notifications", zap.Error(err))
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userId)
+	if err != nil {
+		s.log.Named("GetNotifications").Error("Failed to count unread notifications", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.NotificationListDto{
+		Notifications: ConvertNotificationsToDtos(notifications),
+		UnreadCount:   unread,
+	}, nil
+}
+
+func (s *notificationService) MarkAsRead(userId string, notificationId string) error {
+	affected, err := s.notificationRepo.MarkAsRead(userId, notificationId)
+	if err != nil {
+		s.log.Named("MarkAsRead").Error("Failed to mark notification as read", zap.Error(err))
+		return err
+	}
+	if affected == 0 {
+		return errors.New("notification not found")
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllAsRead(userId string) error {
+	if err := s.notificationRepo.MarkAllAsRead(userId); err != nil {
+		s.log.Named("MarkAllAsRead").Error("Failed to mark notifications as read", zap.Error(err))
+		return err
+	}
+	return nil
+}
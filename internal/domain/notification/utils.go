@@ -0,0 +1,22 @@
+package notification
+
+import "github.com/esc-chula/intania-888-backend/internal/model"
+
+func ConvertNotificationToDto(notification *model.Notification) *model.NotificationDto {
+	return &model.NotificationDto{
+		Id:        notification.Id,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		IsRead:    notification.ReadAt != nil,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func ConvertNotificationsToDtos(notifications []*model.Notification) []*model.NotificationDto {
+	notificationDtos := make([]*model.NotificationDto, len(notifications))
+	for i, notification := range notifications {
+		notificationDtos[i] = ConvertNotificationToDto(notification)
+	}
+	return notificationDtos
+}
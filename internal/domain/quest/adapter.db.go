@@ -0,0 +1,125 @@
+package quest
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type questRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewQuestRepository(db *gorm.DB) QuestRepository {
+	return &questRepositoryImpl{db: db}
+}
+
+func (r *questRepositoryImpl) Create(quest *model.Quest) error {
+	return r.db.Create(quest).Error
+}
+
+func (r *questRepositoryImpl) Update(quest *model.Quest) error {
+	return r.db.Save(quest).Error
+}
+
+func (r *questRepositoryImpl) Delete(questId string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("quest_id = ?", questId).Delete(&model.QuestProgress{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", questId).Delete(&model.Quest{}).Error
+	})
+}
+
+func (r *questRepositoryImpl) FindById(questId string) (*model.Quest, error) {
+	var quest model.Quest
+	if err := r.db.Where("id = ?", questId).First(&quest).Error; err != nil {
+		return nil, err
+	}
+	return &quest, nil
+}
+
+func (r *questRepositoryImpl) FindAll() ([]model.Quest, error) {
+	var quests []model.Quest
+	if err := r.db.Order("created_at DESC").Find(&quests).Error; err != nil {
+		return nil, err
+	}
+	return quests, nil
+}
+
+func (r *questRepositoryImpl) FindActive(now time.Time) ([]model.Quest, error) {
+	var quests []model.Quest
+	err := r.activeScope(now).
+		Order("created_at ASC").
+		Find(&quests).Error
+	if err != nil {
+		return nil, err
+	}
+	return quests, nil
+}
+
+func (r *questRepositoryImpl) FindActiveByGoalTypes(goalTypes []string, now time.Time) ([]model.Quest, error) {
+	var quests []model.Quest
+	err := r.activeScope(now).
+		Where("goal_type IN ?", goalTypes).
+		Find(&quests).Error
+	if err != nil {
+		return nil, err
+	}
+	return quests, nil
+}
+
+func (r *questRepositoryImpl) FindProgressByUserId(userId string) ([]model.QuestProgress, error) {
+	var progress []model.QuestProgress
+	if err := r.db.Where("user_id = ?", userId).Find(&progress).Error; err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (r *questRepositoryImpl) FindBadgesByUserId(userId string) ([]model.UserBadge, error) {
+	var badges []model.UserBadge
+	err := r.db.Where("user_id = ?", userId).
+		Order("created_at ASC").
+		Find(&badges).Error
+	if err != nil {
+		return nil, err
+	}
+	return badges, nil
+}
+
+func (r *questRepositoryImpl) CountSportTypes() (int64, error) {
+	var count int64
+	err := r.db.Model(&model.SportType{}).Count(&count).Error
+	return count, err
+}
+
+// LockProgress returns the user's progress row on a quest FOR UPDATE, creating it first if needed
+func (r *questRepositoryImpl) LockProgress(tx *gorm.DB, questId string, userId string) (*model.QuestProgress, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.QuestProgress{
+		Id:        uuid.New().String(),
+		QuestId:   questId,
+		UserId:    userId,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	var progress model.QuestProgress
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("quest_id = ? AND user_id = ?", questId, userId).
+		First(&progress).Error; err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+func (r *questRepositoryImpl) activeScope(now time.Time) *gorm.DB {
+	return r.db.Where("is_active = ?", true).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at > ?", now)
+}
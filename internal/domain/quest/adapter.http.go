@@ -0,0 +1,198 @@
+package quest
+
+import (
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type QuestHttpHandler struct {
+	service QuestService
+}
+
+func NewQuestHttpHandler(service QuestService) *QuestHttpHandler {
+	return &QuestHttpHandler{service: service}
+}
+
+func (h *QuestHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/quests", mid.AuthMiddleware)
+
+	router.Get("", h.GetUserQuests)
+	router.Get("/badges", h.GetUserBadges)
+	router.Post("/:id/claim", h.ClaimReward)
+
+	adminRouter := router.Group("/admin")
+	adminRouter.Get("", mid.RequirePermission(model.PermQuestConfigure), h.GetAllQuests)
+	adminRouter.Post("", mid.RequirePermission(model.PermQuestConfigure), h.CreateQuest)
+	adminRouter.Put("/:id", mid.RequirePermission(model.PermQuestConfigure), h.UpdateQuest)
+	adminRouter.Delete("/:id", mid.RequirePermission(model.PermQuestConfigure), h.DeleteQuest)
+}
+
+// @Summary Get quests
+// @Description Get the active quests with the user's progress on each
+// @Tags Quest
+// @Produce json
+// @Success 200 {array} model.UserQuestDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /quests [get]
+// @Security BearerAuth
+func (h *QuestHttpHandler) GetUserQuests(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	quests, err := h.service.GetUserQuests(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get quests",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(quests)
+}
+
+// @Summary Get badges
+// @Description Get the badges the user earned from quests
+// @Tags Quest
+// @Produce json
+// @Success 200 {array} model.UserBadgeDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /quests/badges [get]
+// @Security BearerAuth
+func (h *QuestHttpHandler) GetUserBadges(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	badges, err := h.service.GetUserBadges(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get badges",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(badges)
+}
+
+// @Summary Claim a quest reward
+// @Description Claim the coin and badge reward of a completed quest
+// @Tags Quest
+// @Produce json
+// @Param id path string true "Quest ID"
+// @Success 200 {object} model.UserQuestDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /quests/{id}/claim [post]
+// @Security BearerAuth
+func (h *QuestHttpHandler) ClaimReward(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	quest, err := h.service.ClaimReward(profile.Id, c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Quest reward claimed!",
+		"quest":   quest,
+	})
+}
+
+// @Summary Get all quests
+// @Description Get every quest including inactive and expired ones (admin only)
+// @Tags Quest
+// @Produce json
+// @Success 200 {array} model.QuestDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /quests/admin [get]
+// @Security BearerAuth
+func (h *QuestHttpHandler) GetAllQuests(c *fiber.Ctx) error {
+	quests, err := h.service.GetAllQuests()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get quests",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(quests)
+}
+
+// @Summary Create a quest
+// @Description Define a quest goal with a coin or badge reward (admin only)
+// @Tags Quest
+// @Accept json
+// @Produce json
+// @Param request body model.QuestDto true "Quest definition"
+// @Success 201 {object} model.QuestDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /quests/admin [post]
+// @Security BearerAuth
+func (h *QuestHttpHandler) CreateQuest(c *fiber.Ctx) error {
+	var req model.QuestDto
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	quest, err := h.service.CreateQuest(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(quest)
+}
+
+// @Summary Update a quest
+// @Description Replace a quest definition, progress already made is kept (admin only)
+// @Tags Quest
+// @Accept json
+// @Produce json
+// @Param id path string true "Quest ID"
+// @Param request body model.QuestDto true "Quest definition"
+// @Success 200 {object} model.QuestDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /quests/admin/{id} [put]
+// @Security BearerAuth
+func (h *QuestHttpHandler) UpdateQuest(c *fiber.Ctx) error {
+	var req model.QuestDto
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	quest, err := h.service.UpdateQuest(c.Params("id"), &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(quest)
+}
+
+// @Summary Delete a quest
+// @Description Delete a quest and all progress on it (admin only)
+// @Tags Quest
+// @Param id path string true "Quest ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /quests/admin/{id} [delete]
+// @Security BearerAuth
+func (h *QuestHttpHandler) DeleteQuest(c *fiber.Ctx) error {
+	if err := h.service.DeleteQuest(c.Params("id")); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package quest
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// Event is something a player did that may count towards their quests
+type Event struct {
+	Type         string   // bill_placed, bill_won, mines_cashed_out, slot_spin
+	Lines        int      // bill lines for bill events
+	SportTypeIds []string // sport types bet on for bill_placed
+	Multiplier   float64  // final multiplier for mines_cashed_out
+}
+
+// QuestTracker is how other domains report quest progress, tracking never fails the caller
+type QuestTracker interface {
+	Track(userId string, event *Event)
+}
+
+type QuestService interface {
+	QuestTracker
+
+	CreateQuest(req *model.QuestDto) (*model.QuestDto, error)
+	UpdateQuest(questId string, req *model.QuestDto) (*model.QuestDto, error)
+	DeleteQuest(questId string) error
+	GetAllQuests() ([]model.QuestDto, error)
+	GetUserQuests(userId string) ([]model.UserQuestDto, error)
+	ClaimReward(userId string, questId string) (*model.UserQuestDto, error)
+	GetUserBadges(userId string) ([]model.UserBadgeDto, error)
+}
+
+type QuestRepository interface {
+	Create(quest *model.Quest) error
+	Update(quest *model.Quest) error
+	Delete(questId string) error
+	FindById(questId string) (*model.Quest, error)
+	FindAll() ([]model.Quest, error)
+	FindActive(now time.Time) ([]model.Quest, error)
+	FindActiveByGoalTypes(goalTypes []string, now time.Time) ([]model.Quest, error)
+	FindProgressByUserId(userId string) ([]model.QuestProgress, error)
+	FindBadgesByUserId(userId string) ([]model.UserBadge, error)
+	CountSportTypes() (int64, error)
+	LockProgress(tx *gorm.DB, questId string, userId string) (*model.QuestProgress, error)
+}
@@ -0,0 +1,340 @@
+package quest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type questServiceImpl struct {
+	repo   QuestRepository
+	userDB *gorm.DB
+	log    *zap.Logger
+}
+
+func NewQuestService(repo QuestRepository, db *gorm.DB, log *zap.Logger) QuestService {
+	return &questServiceImpl{
+		repo:   repo,
+		userDB: db,
+		log:    log,
+	}
+}
+
+// Track advances every active quest the event counts towards. Failures are logged and never
+// returned, the action that produced the event has already happened.
+func (s *questServiceImpl) Track(userId string, event *Event) {
+	goalTypes, ok := eventGoals[event.Type]
+	if !ok {
+		s.log.Named("Track").Warn("Unknown quest event", zap.String("event", event.Type))
+		return
+	}
+
+	quests, err := s.repo.FindActiveByGoalTypes(goalTypes, time.Now())
+	if err != nil {
+		s.log.Named("Track").Error("Failed to find quests", zap.Error(err), zap.String("event", event.Type))
+		return
+	}
+
+	for i := range quests {
+		quest := &quests[i]
+		if quest.GoalType != GoalBetAllSportTypes && EventProgress(quest.GoalType, quest.Threshold, event) == 0 {
+			continue
+		}
+		if quest.GoalType == GoalBetAllSportTypes && len(event.SportTypeIds) == 0 {
+			continue
+		}
+
+		if err := s.advance(userId, quest, event); err != nil {
+			s.log.Named("Track").Error("Failed to advance quest", zap.Error(err),
+				zap.String("questId", quest.Id),
+				zap.String("userId", userId))
+		}
+	}
+}
+
+func (s *questServiceImpl) advance(userId string, quest *model.Quest, event *Event) error {
+	return s.userDB.Transaction(func(tx *gorm.DB) error {
+		progress, err := s.repo.LockProgress(tx, quest.Id, userId)
+		if err != nil {
+			return err
+		}
+		if progress.CompletedAt != nil {
+			return nil
+		}
+
+		if quest.GoalType == GoalBetAllSportTypes {
+			data, covered, err := MergeSportTypes(progress.Data, event.SportTypeIds)
+			if err != nil {
+				return err
+			}
+			progress.Data = data
+			progress.Progress = covered
+		} else {
+			progress.Progress += EventProgress(quest.GoalType, quest.Threshold, event)
+		}
+
+		now := time.Now()
+		if progress.Progress >= quest.Target {
+			progress.Progress = quest.Target
+			progress.CompletedAt = &now
+			s.log.Named("advance").Info("Quest completed", zap.String("questId", quest.Id), zap.String("userId", userId))
+		}
+		progress.UpdatedAt = now
+
+		return tx.Save(progress).Error
+	})
+}
+
+func (s *questServiceImpl) CreateQuest(req *model.QuestDto) (*model.QuestDto, error) {
+	quest := &model.Quest{
+		Id:        uuid.New().String(),
+		CreatedAt: time.Now(),
+	}
+	if err := s.applyQuestDto(quest, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(quest); err != nil {
+		s.log.Named("CreateQuest").Error("Failed to create quest", zap.Error(err))
+		return nil, errors.New("failed to create quest")
+	}
+
+	s.log.Named("CreateQuest").Info("Quest created", zap.String("questId", quest.Id), zap.String("goal", quest.GoalType))
+	return questToDto(quest), nil
+}
+
+func (s *questServiceImpl) UpdateQuest(questId string, req *model.QuestDto) (*model.QuestDto, error) {
+	quest, err := s.repo.FindById(questId)
+	if err != nil {
+		return nil, errors.New("quest not found")
+	}
+	if err := s.applyQuestDto(quest, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(quest); err != nil {
+		s.log.Named("UpdateQuest").Error("Failed to update quest", zap.Error(err))
+		return nil, errors.New("failed to update quest")
+	}
+
+	return questToDto(quest), nil
+}
+
+func (s *questServiceImpl) DeleteQuest(questId string) error {
+	if err := s.repo.Delete(questId); err != nil {
+		s.log.Named("DeleteQuest").Error("Failed to delete quest", zap.Error(err))
+		return errors.New("failed to delete quest")
+	}
+
+	s.log.Named("DeleteQuest").Info("Quest deleted", zap.String("questId", questId))
+	return nil
+}
+
+func (s *questServiceImpl) GetAllQuests() ([]model.QuestDto, error) {
+	quests, err := s.repo.FindAll()
+	if err != nil {
+		s.log.Named("GetAllQuests").Error("Failed to get quests", zap.Error(err))
+		return nil, err
+	}
+
+	questDtos := make([]model.QuestDto, len(quests))
+	for i := range quests {
+		questDtos[i] = *questToDto(&quests[i])
+	}
+	return questDtos, nil
+}
+
+// GetUserQuests returns the active quests along with the user's progress on each
+func (s *questServiceImpl) GetUserQuests(userId string) ([]model.UserQuestDto, error) {
+	quests, err := s.repo.FindActive(time.Now())
+	if err != nil {
+		s.log.Named("GetUserQuests").Error("Failed to get quests", zap.Error(err))
+		return nil, err
+	}
+
+	progress, err := s.repo.FindProgressByUserId(userId)
+	if err != nil {
+		s.log.Named("GetUserQuests").Error("Failed to get progress", zap.Error(err))
+		return nil, err
+	}
+
+	progressByQuest := make(map[string]*model.QuestProgress, len(progress))
+	for i := range progress {
+		progressByQuest[progress[i].QuestId] = &progress[i]
+	}
+
+	userQuests := make([]model.UserQuestDto, len(quests))
+	for i := range quests {
+		userQuests[i] = *userQuestToDto(&quests[i], progressByQuest[quests[i].Id])
+	}
+	return userQuests, nil
+}
+
+// ClaimReward pays out a completed quest once, crediting coins and granting the badge together
+func (s *questServiceImpl) ClaimReward(userId string, questId string) (*model.UserQuestDto, error) {
+	quest, err := s.repo.FindById(questId)
+	if err != nil {
+		return nil, errors.New("quest not found")
+	}
+
+	var progress *model.QuestProgress
+
+	err = s.userDB.Transaction(func(tx *gorm.DB) error {
+		if _, err := stakegame.LockUser(tx, userId); err != nil {
+			s.log.Named("ClaimReward").Error("User not found", zap.Error(err))
+			return err
+		}
+
+		progress, err = s.repo.LockProgress(tx, questId, userId)
+		if err != nil {
+			s.log.Named("ClaimReward").Error("Failed to load progress", zap.Error(err))
+			return errors.New("failed to load quest progress")
+		}
+		if progress.CompletedAt == nil {
+			return errors.New("quest is not completed yet")
+		}
+		if progress.ClaimedAt != nil {
+			return errors.New("quest reward already claimed")
+		}
+
+		now := time.Now()
+		progress.ClaimedAt = &now
+		progress.UpdatedAt = now
+		if err := tx.Save(progress).Error; err != nil {
+			s.log.Named("ClaimReward").Error("Failed to update progress", zap.Error(err))
+			return errors.New("failed to claim reward")
+		}
+
+		if quest.RewardBadge != "" {
+			badge := &model.UserBadge{
+				Id:        uuid.New().String(),
+				UserId:    userId,
+				Badge:     quest.RewardBadge,
+				QuestId:   &quest.Id,
+				CreatedAt: now,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(badge).Error; err != nil {
+				s.log.Named("ClaimReward").Error("Failed to grant badge", zap.Error(err))
+				return errors.New("failed to claim reward")
+			}
+		}
+
+		if err := stakegame.Credit(tx, userId, quest.RewardCoins); err != nil {
+			s.log.Named("ClaimReward").Error("Failed to credit reward", zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Named("ClaimReward").Info("Quest reward claimed",
+		zap.String("questId", questId),
+		zap.String("userId", userId),
+		zap.Float64("coins", quest.RewardCoins),
+		zap.String("badge", quest.RewardBadge))
+	return userQuestToDto(quest, progress), nil
+}
+
+func (s *questServiceImpl) GetUserBadges(userId string) ([]model.UserBadgeDto, error) {
+	badges, err := s.repo.FindBadgesByUserId(userId)
+	if err != nil {
+		s.log.Named("GetUserBadges").Error("Failed to get badges", zap.Error(err))
+		return nil, err
+	}
+
+	badgeDtos := make([]model.UserBadgeDto, len(badges))
+	for i, badge := range badges {
+		badgeDtos[i] = model.UserBadgeDto{
+			Badge:     badge.Badge,
+			QuestId:   badge.QuestId,
+			CreatedAt: badge.CreatedAt,
+		}
+	}
+	return badgeDtos, nil
+}
+
+// applyQuestDto validates an admin quest definition and copies it onto the entity
+func (s *questServiceImpl) applyQuestDto(quest *model.Quest, req *model.QuestDto) error {
+	if req.Title == "" {
+		return errors.New("title is required")
+	}
+	if !ValidateGoalType(req.GoalType) {
+		return errors.New("invalid goal type")
+	}
+	if req.RewardCoins < 0 {
+		return errors.New("reward coins cannot be negative")
+	}
+	if req.RewardCoins == 0 && req.RewardBadge == "" {
+		return errors.New("quest needs a coin or badge reward")
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return errors.New("quest must end after it starts")
+	}
+	if req.GoalType == GoalMinesMultiplier && req.Threshold <= 1 {
+		return errors.New("mines multiplier quests need a threshold greater than 1")
+	}
+
+	target := req.Target
+	if target == 0 && req.GoalType == GoalBetAllSportTypes {
+		count, err := s.repo.CountSportTypes()
+		if err != nil {
+			s.log.Named("applyQuestDto").Error("Failed to count sport types", zap.Error(err))
+			return errors.New("failed to count sport types")
+		}
+		target = int(count)
+	}
+	if target < 1 {
+		return errors.New("target must be at least 1")
+	}
+
+	quest.Title = req.Title
+	quest.Description = req.Description
+	quest.GoalType = req.GoalType
+	quest.Target = target
+	quest.Threshold = req.Threshold
+	quest.RewardCoins = req.RewardCoins
+	quest.RewardBadge = req.RewardBadge
+	quest.IsActive = req.IsActive
+	quest.StartsAt = req.StartsAt
+	quest.EndsAt = req.EndsAt
+	quest.UpdatedAt = time.Now()
+	return nil
+}
+
+func questToDto(quest *model.Quest) *model.QuestDto {
+	return &model.QuestDto{
+		Id:          quest.Id,
+		Title:       quest.Title,
+		Description: quest.Description,
+		GoalType:    quest.GoalType,
+		Target:      quest.Target,
+		Threshold:   quest.Threshold,
+		RewardCoins: quest.RewardCoins,
+		RewardBadge: quest.RewardBadge,
+		IsActive:    quest.IsActive,
+		StartsAt:    quest.StartsAt,
+		EndsAt:      quest.EndsAt,
+	}
+}
+
+func userQuestToDto(quest *model.Quest, progress *model.QuestProgress) *model.UserQuestDto {
+	userQuest := &model.UserQuestDto{QuestDto: *questToDto(quest)}
+	if progress != nil {
+		userQuest.Progress = progress.Progress
+		userQuest.Completed = progress.CompletedAt != nil
+		userQuest.Claimed = progress.ClaimedAt != nil
+		userQuest.CompletedAt = progress.CompletedAt
+		userQuest.ClaimedAt = progress.ClaimedAt
+	}
+	return userQuest
+}
@@ -0,0 +1,88 @@
+package quest
+
+import "encoding/json"
+
+// Goal types admins can build quests from
+const (
+	GoalPlaceBills       = "place_bills"
+	GoalWinAccumulator   = "win_accumulator"
+	GoalMinesMultiplier  = "mines_multiplier"
+	GoalSlotSpins        = "slot_spins"
+	GoalBetAllSportTypes = "bet_all_sport_types"
+)
+
+// defaultAccumulatorLines is the minimum bill lines for win_accumulator quests without a threshold
+const defaultAccumulatorLines = 3
+
+// Events reported by other domains
+const (
+	EventBillPlaced     = "bill_placed"
+	EventBillWon        = "bill_won"
+	EventMinesCashedOut = "mines_cashed_out"
+	EventSlotSpin       = "slot_spin"
+)
+
+// eventGoals lists the goal types each event can advance
+var eventGoals = map[string][]string{
+	EventBillPlaced:     {GoalPlaceBills, GoalBetAllSportTypes},
+	EventBillWon:        {GoalWinAccumulator},
+	EventMinesCashedOut: {GoalMinesMultiplier},
+	EventSlotSpin:       {GoalSlotSpins},
+}
+
+// ValidateGoalType checks if the goal type is valid
+func ValidateGoalType(goalType string) bool {
+	switch goalType {
+	case GoalPlaceBills, GoalWinAccumulator, GoalMinesMultiplier, GoalSlotSpins, GoalBetAllSportTypes:
+		return true
+	}
+	return false
+}
+
+// EventProgress returns how much an event counts towards a counting goal
+func EventProgress(goalType string, threshold float64, event *Event) int {
+	switch goalType {
+	case GoalPlaceBills, GoalSlotSpins:
+		return 1
+	case GoalWinAccumulator:
+		minLines := int(threshold)
+		if minLines <= 0 {
+			minLines = defaultAccumulatorLines
+		}
+		if event.Lines >= minLines {
+			return 1
+		}
+	case GoalMinesMultiplier:
+		if event.Multiplier >= threshold {
+			return 1
+		}
+	}
+	return 0
+}
+
+// MergeSportTypes adds sport type ids to the JSON set stored on a progress row
+func MergeSportTypes(data string, sportTypeIds []string) (string, int, error) {
+	var seen []string
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &seen); err != nil {
+			return "", 0, err
+		}
+	}
+
+	set := make(map[string]bool, len(seen))
+	for _, id := range seen {
+		set[id] = true
+	}
+	for _, id := range sportTypeIds {
+		if !set[id] {
+			set[id] = true
+			seen = append(seen, id)
+		}
+	}
+
+	merged, err := json.Marshal(seen)
+	if err != nil {
+		return "", 0, err
+	}
+	return string(merged), len(seen), nil
+}
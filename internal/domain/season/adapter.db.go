@@ -0,0 +1,271 @@
+package season
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type seasonRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSeasonRepository(db *gorm.DB) SeasonRepository {
+	return &seasonRepositoryImpl{db: db}
+}
+
+func (r *seasonRepositoryImpl) FindActive() (*model.Season, error) {
+	var season model.Season
+	if err := r.db.Where("status = ?", model.SeasonActive).First(&season).Error; err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *seasonRepositoryImpl) FindAll() ([]model.Season, error) {
+	var seasons []model.Season
+	if err := r.db.Order("started_at DESC").Find(&seasons).Error; err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *seasonRepositoryImpl) FindById(seasonId string) (*model.Season, error) {
+	var season model.Season
+	if err := r.db.Where("id = ?", seasonId).First(&season).Error; err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *seasonRepositoryImpl) FindStandings(seasonId string) ([]model.SeasonStanding, error) {
+	var standings []model.SeasonStanding
+	err := r.db.Preload("Color").
+		Where("season_id = ?", seasonId).
+		Order("rank ASC").
+		Find(&standings).Error
+	if err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
+
+func (r *seasonRepositoryImpl) FindBalances(seasonId string, limit int) ([]model.SeasonBalance, error) {
+	var balances []model.SeasonBalance
+	err := r.db.Preload("User").
+		Joins("JOIN users ON users.id = season_balances.user_id").
+		Where("season_balances.season_id = ? AND NOT users.hide_from_leaderboard", seasonId).
+		Order("season_balances.rank ASC").
+		Limit(limit).
+		Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *seasonRepositoryImpl) FindBalance(seasonId string, userId string) (*model.SeasonBalance, error) {
+	var balance model.SeasonBalance
+	err := r.db.Preload("User").
+		Where("season_id = ? AND user_id = ?", seasonId, userId).
+		First(&balance).Error
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// Rollover archives the active season's balances, color standings and totals, then starts
+// next and resets every balance to its starting coins, all in one transaction.
+// Every user is locked before anything is checked, so bets that lock their user first either land
+// before the checks see them or wait and are stamped with the next season.
+func (r *seasonRepositoryImpl) Rollover(next *model.Season) (*model.Season, error) {
+	var current model.Season
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ?", model.SeasonActive).
+			First(&current).Error; err != nil {
+			return errors.New("no active season")
+		}
+
+		var lockedUsers []string
+		if err := tx.Model(&model.User{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Order("id ASC").
+			Pluck("id", &lockedUsers).Error; err != nil {
+			return err
+		}
+
+		// Anything still in play would pay out into the next season's balances
+		var activeGames int64
+		if err := tx.Model(&model.MineGame{}).Where("status = ?", "active").Count(&activeGames).Error; err != nil {
+			return err
+		}
+		if activeGames > 0 {
+			return fmt.Errorf("%d mines games are still active", activeGames)
+		}
+
+		var activeCrashBets int64
+		if err := tx.Model(&model.CrashBet{}).Where("status = ?", "active").Count(&activeCrashBets).Error; err != nil {
+			return err
+		}
+		if activeCrashBets > 0 {
+			return fmt.Errorf("%d crash bets are still active", activeCrashBets)
+		}
+
+		var openLotteries int64
+		if err := tx.Model(&model.Lottery{}).Where("status = ?", "open").Count(&openLotteries).Error; err != nil {
+			return err
+		}
+		if openLotteries > 0 {
+			return fmt.Errorf("%d lotteries are still open", openLotteries)
+		}
+
+		var openPools int64
+		if err := tx.Model(&model.TeamPool{}).
+			Where("status = ? AND total_amount > 0", "open").
+			Count(&openPools).Error; err != nil {
+			return err
+		}
+		if openPools > 0 {
+			return fmt.Errorf("%d team pools are still open", openPools)
+		}
+
+		var unpaidLines int64
+		if err := tx.Model(&model.BillLine{}).
+			Joins("JOIN bill_heads ON bill_heads.id = bill_lines.bill_id").
+			Where("bill_heads.season_id = ? AND NOT bill_lines.is_paid", current.Id).
+			Count(&unpaidLines).Error; err != nil {
+			return err
+		}
+		if unpaidLines > 0 {
+			return fmt.Errorf("%d bill lines are still waiting for match results", unpaidLines)
+		}
+
+		now := time.Now()
+
+		if err := archiveBalances(tx, current.Id, now); err != nil {
+			return err
+		}
+		if err := archiveStandings(tx, current.Id, now); err != nil {
+			return err
+		}
+		if err := countTotals(tx, &current); err != nil {
+			return err
+		}
+
+		current.Status = model.SeasonArchived
+		current.EndedAt = &now
+		current.UpdatedAt = now
+		if err := tx.Save(&current).Error; err != nil {
+			return err
+		}
+
+		next.Status = model.SeasonActive
+		next.StartedAt = now
+		next.CreatedAt = now
+		next.UpdatedAt = now
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.User{}).
+			Where("1 = 1").
+			Update("remaining_coin", next.StartingCoins).Error; err != nil {
+			return err
+		}
+
+		// Streaks restart with the season, claims stay behind with their season id
+		return tx.Where("1 = 1").Delete(&model.DailyRewardStreak{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &current, nil
+}
+
+func archiveBalances(tx *gorm.DB, seasonId string, now time.Time) error {
+	var users []model.User
+	if err := tx.Select("id", "remaining_coin").
+		Order("remaining_coin DESC, id ASC").
+		Find(&users).Error; err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	balances := make([]model.SeasonBalance, len(users))
+	for i, user := range users {
+		balances[i] = model.SeasonBalance{
+			Id:        uuid.NewString(),
+			SeasonId:  seasonId,
+			UserId:    user.Id,
+			Coins:     user.RemainingCoin,
+			Rank:      i + 1,
+			CreatedAt: now,
+		}
+	}
+	return tx.CreateInBatches(balances, 500).Error
+}
+
+func archiveStandings(tx *gorm.DB, seasonId string, now time.Time) error {
+	var standings []model.SeasonStanding
+	err := tx.Table("colors").
+		Select(`colors.id AS color_id,
+			COUNT(matches.id) AS total_matches,
+			COUNT(matches.id) FILTER (WHERE matches.is_draw) AS drawn,
+			COUNT(matches.id) FILTER (WHERE matches.winner_id = colors.id) AS won`).
+		Joins(`LEFT JOIN matches
+			ON (matches.winner_id IS NOT NULL OR matches.is_draw = TRUE)
+			AND (colors.id = matches.teama_id OR colors.id = matches.teamb_id)
+			AND matches.season_id = ?`, seasonId).
+		Group("colors.id").
+		Scan(&standings).Error
+	if err != nil {
+		return err
+	}
+	if len(standings) == 0 {
+		return nil
+	}
+
+	for i := range standings {
+		standings[i].Id = uuid.NewString()
+		standings[i].SeasonId = seasonId
+		standings[i].Lost = standings[i].TotalMatches - standings[i].Won - standings[i].Drawn
+		standings[i].CreatedAt = now
+	}
+	RankStandings(standings)
+
+	return tx.Create(&standings).Error
+}
+
+func countTotals(tx *gorm.DB, season *model.Season) error {
+	var bills, mineGames int64
+	if err := tx.Model(&model.BillHead{}).Where("season_id = ?", season.Id).Count(&bills).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.MineGame{}).Where("season_id = ?", season.Id).Count(&mineGames).Error; err != nil {
+		return err
+	}
+
+	var dailyRewards float64
+	if err := tx.Model(&model.DailyRewardClaim{}).
+		Where("season_id = ?", season.Id).
+		Select("COALESCE(SUM(reward), 0)").
+		Scan(&dailyRewards).Error; err != nil {
+		return err
+	}
+
+	season.TotalBills = int(bills)
+	season.TotalMineGames = int(mineGames)
+	season.TotalDailyRewards = dailyRewards
+	return nil
+}
@@ -0,0 +1,127 @@
+package season
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SeasonHttpHandler struct {
+	service SeasonService
+}
+
+func NewSeasonHttpHandler(service SeasonService) *SeasonHttpHandler {
+	return &SeasonHttpHandler{service: service}
+}
+
+func (h *SeasonHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/seasons", mid.AuthMiddleware)
+
+	router.Get("", h.GetSeasons)
+	router.Get("/current", h.GetCurrentSeason)
+	router.Get("/:id/results", h.GetSeasonResult)
+
+	adminRouter := router.Group("/admin")
+	adminRouter.Post("/rollover", mid.RequirePermission(model.PermSeasonConfigure), h.StartNextSeason)
+}
+
+// @Summary Get seasons
+// @Description Get every season, newest first
+// @Tags Season
+// @Produce json
+// @Success 200 {array} model.SeasonDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /seasons [get]
+// @Security BearerAuth
+func (h *SeasonHttpHandler) GetSeasons(c *fiber.Ctx) error {
+	seasons, err := h.service.GetSeasons()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get seasons",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seasons)
+}
+
+// @Summary Get current season
+// @Description Get the season in progress
+// @Tags Season
+// @Produce json
+// @Success 200 {object} model.SeasonDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /seasons/current [get]
+// @Security BearerAuth
+func (h *SeasonHttpHandler) GetCurrentSeason(c *fiber.Ctx) error {
+	season, err := h.service.GetCurrentSeason()
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(season)
+}
+
+// @Summary Get season results
+// @Description Get the final color standings and top balances of an archived season with the user's own final balance
+// @Tags Season
+// @Produce json
+// @Param id path string true "Season ID"
+// @Param limit query int false "Balances to return" default(20)
+// @Success 200 {object} model.SeasonResultDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /seasons/{id}/results [get]
+// @Security BearerAuth
+func (h *SeasonHttpHandler) GetSeasonResult(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	result, err := h.service.GetSeasonResult(profile.Id, c.Params("id"), limit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Start next season
+// @Description Archive the active season's color standings and balances, then start a new season and reset every balance to the starting coins (admin only). Fails while mines games are active or bills are waiting for results.
+// @Tags Season
+// @Accept json
+// @Produce json
+// @Param request body model.StartSeasonRequest true "New season"
+// @Success 201 {object} model.SeasonDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /seasons/admin/rollover [post]
+// @Security BearerAuth
+func (h *SeasonHttpHandler) StartNextSeason(c *fiber.Ctx) error {
+	var req model.StartSeasonRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	season, err := h.service.StartNextSeason(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(season)
+}
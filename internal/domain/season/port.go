@@ -0,0 +1,20 @@
+package season
+
+import "github.com/esc-chula/intania-888-backend/internal/model"
+
+type SeasonService interface {
+	GetSeasons() ([]model.SeasonDto, error)
+	GetCurrentSeason() (*model.SeasonDto, error)
+	GetSeasonResult(userId string, seasonId string, limit int) (*model.SeasonResultDto, error)
+	StartNextSeason(req *model.StartSeasonRequest) (*model.SeasonDto, error)
+}
+
+type SeasonRepository interface {
+	FindActive() (*model.Season, error)
+	FindAll() ([]model.Season, error)
+	FindById(seasonId string) (*model.Season, error)
+	FindStandings(seasonId string) ([]model.SeasonStanding, error)
+	FindBalances(seasonId string, limit int) ([]model.SeasonBalance, error)
+	FindBalance(seasonId string, userId string) (*model.SeasonBalance, error)
+	Rollover(next *model.Season) (*model.Season, error)
+}
@@ -0,0 +1,135 @@
+package season
+
+import (
+	"errors"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type seasonServiceImpl struct {
+	repo SeasonRepository
+	log  *zap.Logger
+}
+
+func NewSeasonService(repo SeasonRepository, log *zap.Logger) SeasonService {
+	return &seasonServiceImpl{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (s *seasonServiceImpl) GetSeasons() ([]model.SeasonDto, error) {
+	seasons, err := s.repo.FindAll()
+	if err != nil {
+		s.log.Named("GetSeasons").Error("FindAll", zap.Error(err))
+		return nil, err
+	}
+
+	seasonDtos := make([]model.SeasonDto, len(seasons))
+	for i := range seasons {
+		seasonDtos[i] = seasonToDto(&seasons[i])
+	}
+	return seasonDtos, nil
+}
+
+func (s *seasonServiceImpl) GetCurrentSeason() (*model.SeasonDto, error) {
+	season, err := s.repo.FindActive()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("no active season")
+	}
+	if err != nil {
+		s.log.Named("GetCurrentSeason").Error("FindActive", zap.Error(err))
+		return nil, err
+	}
+
+	seasonDto := seasonToDto(season)
+	return &seasonDto, nil
+}
+
+// GetSeasonResult returns the archived standings and final balances of a past season
+func (s *seasonServiceImpl) GetSeasonResult(userId string, seasonId string, limit int) (*model.SeasonResultDto, error) {
+	season, err := s.repo.FindById(seasonId)
+	if err != nil {
+		return nil, errors.New("season not found")
+	}
+	if season.Status != model.SeasonArchived {
+		return nil, errors.New("season is still in progress")
+	}
+
+	standings, err := s.repo.FindStandings(seasonId)
+	if err != nil {
+		s.log.Named("GetSeasonResult").Error("FindStandings", zap.Error(err))
+		return nil, err
+	}
+	balances, err := s.repo.FindBalances(seasonId, limit)
+	if err != nil {
+		s.log.Named("GetSeasonResult").Error("FindBalances", zap.Error(err))
+		return nil, err
+	}
+
+	result := &model.SeasonResultDto{
+		Season:    seasonToDto(season),
+		Standings: make([]model.SeasonStandingDto, len(standings)),
+		Balances:  make([]model.SeasonBalanceDto, len(balances)),
+	}
+	for i, standing := range standings {
+		result.Standings[i] = model.SeasonStandingDto{
+			Rank:         standing.Rank,
+			ColorId:      standing.ColorId,
+			Title:        standing.Color.Title,
+			TotalMatches: standing.TotalMatches,
+			Won:          standing.Won,
+			Drawn:        standing.Drawn,
+			Lost:         standing.Lost,
+		}
+	}
+	for i := range balances {
+		result.Balances[i] = balanceToDto(&balances[i])
+	}
+
+	// Users who joined after the season ended have no balance in it
+	if balance, err := s.repo.FindBalance(seasonId, userId); err == nil {
+		me := balanceToDto(balance)
+		result.Me = &me
+	}
+
+	return result, nil
+}
+
+// StartNextSeason archives the active season and starts a new one with fresh balances
+func (s *seasonServiceImpl) StartNextSeason(req *model.StartSeasonRequest) (*model.SeasonDto, error) {
+	if req.Name == "" {
+		return nil, errors.New("season name is required")
+	}
+	if req.StartingCoins < 0 {
+		return nil, errors.New("starting coins cannot be negative")
+	}
+
+	startingCoins := req.StartingCoins
+	if startingCoins == 0 {
+		startingCoins = model.DefaultStartingCoins
+	}
+
+	next := &model.Season{
+		Id:            uuid.NewString(),
+		Name:          req.Name,
+		StartingCoins: startingCoins,
+	}
+
+	archived, err := s.repo.Rollover(next)
+	if err != nil {
+		s.log.Named("StartNextSeason").Warn("Rollover", zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Named("StartNextSeason").Info("Started new season",
+		zap.String("archived_season_id", archived.Id),
+		zap.String("season_id", next.Id),
+		zap.Float64("starting_coins", startingCoins))
+
+	seasonDto := seasonToDto(next)
+	return &seasonDto, nil
+}
@@ -0,0 +1,51 @@
+package season
+
+import (
+	"sort"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+// RankStandings orders colors by wins, then draws, then fewest losses and numbers them from 1
+func RankStandings(standings []model.SeasonStanding) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Won != b.Won {
+			return a.Won > b.Won
+		}
+		if a.Drawn != b.Drawn {
+			return a.Drawn > b.Drawn
+		}
+		if a.Lost != b.Lost {
+			return a.Lost < b.Lost
+		}
+		return a.ColorId < b.ColorId
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+}
+
+func seasonToDto(season *model.Season) model.SeasonDto {
+	return model.SeasonDto{
+		Id:                season.Id,
+		Name:              season.Name,
+		Status:            season.Status,
+		StartingCoins:     season.StartingCoins,
+		TotalBills:        season.TotalBills,
+		TotalMineGames:    season.TotalMineGames,
+		TotalDailyRewards: season.TotalDailyRewards,
+		StartedAt:         season.StartedAt,
+		EndedAt:           season.EndedAt,
+	}
+}
+
+func balanceToDto(balance *model.SeasonBalance) model.SeasonBalanceDto {
+	return model.SeasonBalanceDto{
+		Rank:     balance.Rank,
+		UserId:   balance.UserId,
+		Name:     balance.User.Name,
+		NickName: balance.User.NickName,
+		Coins:    balance.Coins,
+	}
+}
@@ -0,0 +1,101 @@
+package stakegame
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gameRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewGameRepository(db *gorm.DB) GameRepository {
+	return &gameRepositoryImpl{db: db}
+}
+
+func (r *gameRepositoryImpl) FindRoundsByUserId(userId string, game string, limit int, offset int) ([]model.GameRound, error) {
+	var rounds []model.GameRound
+	err := r.db.Preload("Seed").
+		Where("user_id = ? AND game = ?", userId, game).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&rounds).Error
+	if err != nil {
+		return nil, err
+	}
+	return rounds, nil
+}
+
+func (r *gameRepositoryImpl) GetStatsByUserId(userId string, game string) (*model.GameStatsDto, error) {
+	var stats model.GameStatsDto
+
+	err := r.db.Model(&model.GameRound{}).
+		Where("user_id = ? AND game = ?", userId, game).
+		Select(`COUNT(*) AS total_games,
+			COUNT(*) FILTER (WHERE won) AS games_won,
+			COALESCE(SUM(bet_amount), 0) AS total_wagered,
+			COALESCE(SUM(payout), 0) AS total_winnings,
+			COALESCE(MAX(payout), 0) AS biggest_win`).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Game = game
+	stats.GamesLost = stats.TotalGames - stats.GamesWon
+	stats.NetProfit = stats.TotalWinnings - stats.TotalWagered
+	if stats.TotalGames > 0 {
+		stats.WinRate = float64(stats.GamesWon) / float64(stats.TotalGames) * 100
+	}
+
+	return &stats, nil
+}
+
+func (r *gameRepositoryImpl) FindActiveSeed(userId string, game string) (*model.GameSeed, error) {
+	var seed model.GameSeed
+	if err := r.db.Where("user_id = ? AND game = ? AND is_active = ?", userId, game, true).First(&seed).Error; err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+func (r *gameRepositoryImpl) CreateSeed(seed *model.GameSeed) error {
+	return r.db.Create(seed).Error
+}
+
+// RotateSeed reveals the user's active seed for game and replaces it with next, returning the revealed seed if there was one
+func (r *gameRepositoryImpl) RotateSeed(userId string, game string, next *model.GameSeed) (*model.GameSeed, error) {
+	var previous *model.GameSeed
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var seed model.GameSeed
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND game = ? AND is_active = ?", userId, game, true).
+			First(&seed).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			now := time.Now()
+			if err := tx.Model(&model.GameSeed{}).Where("id = ?", seed.Id).
+				Updates(map[string]interface{}{"is_active": false, "revealed_at": now}).Error; err != nil {
+				return err
+			}
+			seed.IsActive = false
+			seed.RevealedAt = &now
+			previous = &seed
+		}
+
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
@@ -0,0 +1,40 @@
+package stakegame
+
+import (
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+// Wager is a single bet placed on one of the instant games
+type Wager struct {
+	UserId     string
+	Game       string
+	BetAmount  float64
+	Choice     string // what the player picked, e.g. heads or over 50.00
+	ClientSeed string // overrides the committed seed's client seed for this round when set
+}
+
+// Outcome is what a game resolves a roll into
+type Outcome struct {
+	Won        bool
+	Multiplier float64 // payout multiplier applied to the bet on a win
+	Result     string  // what was rolled, e.g. tails or 57.32
+}
+
+// Resolver turns a provably fair roll in [0, 1) into the outcome of a wager
+type Resolver func(roll float64) Outcome
+
+type GameService interface {
+	Play(wager *Wager, resolve Resolver) (*model.GameRoundDto, error)
+	GetHistory(userId string, game string, limit int, offset int) ([]model.GameRoundDto, error)
+	GetStats(userId string, game string) (*model.GameStatsDto, error)
+	GetSeed(userId string, game string) (*model.GameSeedDto, error)
+	RotateSeed(userId string, game string, clientSeed string) (*model.RotateGameSeedResponse, error)
+}
+
+type GameRepository interface {
+	FindRoundsByUserId(userId string, game string, limit int, offset int) ([]model.GameRound, error)
+	GetStatsByUserId(userId string, game string) (*model.GameStatsDto, error)
+	FindActiveSeed(userId string, game string) (*model.GameSeed, error)
+	CreateSeed(seed *model.GameSeed) error
+	RotateSeed(userId string, game string, next *model.GameSeed) (*model.GameSeed, error)
+}
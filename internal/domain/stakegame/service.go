@@ -0,0 +1,261 @@
+package stakegame
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type gameServiceImpl struct {
+	repo   GameRepository
+	userDB *gorm.DB
+	log    *zap.Logger
+}
+
+func NewGameService(repo GameRepository, db *gorm.DB, log *zap.Logger) GameService {
+	return &gameServiceImpl{
+		repo:   repo,
+		userDB: db,
+		log:    log,
+	}
+}
+
+// Play debits the wager, rolls a provably fair number from the user's committed seed, resolves it,
+// records the round and credits the payout, all in one transaction on the locked user
+func (s *gameServiceImpl) Play(wager *Wager, resolve Resolver) (*model.GameRoundDto, error) {
+	if err := ValidateBetAmount(wager.BetAmount); err != nil {
+		return nil, err
+	}
+
+	// The seed hash must be committed before the round, so make sure one exists to be shown
+	if _, err := s.getActiveSeed(wager.UserId, wager.Game); err != nil {
+		s.log.Named("Play").Error("Failed to commit server seed", zap.Error(err))
+		return nil, errors.New("failed to load server seed")
+	}
+
+	var round *model.GameRound
+
+	err := s.userDB.Transaction(func(tx *gorm.DB) error {
+		user, err := LockUser(tx, wager.UserId)
+		if err != nil {
+			s.log.Named("Play").Error("User not found", zap.Error(err))
+			return err
+		}
+
+		if err := Debit(tx, user, wager.BetAmount); err != nil {
+			s.log.Named("Play").Warn("Failed to debit wager",
+				zap.Error(err),
+				zap.String("userId", wager.UserId),
+				zap.Float64("bet", wager.BetAmount))
+			return err
+		}
+
+		seed, err := useSeed(tx, wager.UserId, wager.Game)
+		if err != nil {
+			s.log.Named("Play").Error("Failed to use server seed", zap.Error(err))
+			return errors.New("failed to load server seed")
+		}
+
+		clientSeed := seed.ClientSeed
+		if wager.ClientSeed != "" {
+			clientSeed = wager.ClientSeed
+		}
+
+		roll := utils.FairFloats(seed.ServerSeed, clientSeed, seed.Nonce, 1)[0]
+		outcome := resolve(roll)
+
+		payout := 0.0
+		if outcome.Won {
+			payout = CalculatePayout(wager.BetAmount, outcome.Multiplier)
+		}
+
+		round = &model.GameRound{
+			Id:             uuid.New().String(),
+			UserId:         wager.UserId,
+			Game:           wager.Game,
+			BetAmount:      wager.BetAmount,
+			Choice:         wager.Choice,
+			Result:         outcome.Result,
+			Won:            outcome.Won,
+			Multiplier:     outcome.Multiplier,
+			Payout:         payout,
+			SeedId:         &seed.Id,
+			ServerSeedHash: seed.ServerSeedHash,
+			ClientSeed:     clientSeed,
+			Nonce:          seed.Nonce,
+			CreatedAt:      time.Now(),
+		}
+
+		if err := tx.Create(round).Error; err != nil {
+			s.log.Named("Play").Error("Failed to record round", zap.Error(err))
+			return errors.New("failed to record game")
+		}
+
+		if err := Credit(tx, wager.UserId, payout); err != nil {
+			s.log.Named("Play").Error("Failed to credit winnings", zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Named("Play").Info("Round played",
+		zap.String("game", round.Game),
+		zap.String("userId", round.UserId),
+		zap.Float64("bet", round.BetAmount),
+		zap.Float64("payout", round.Payout))
+	return roundToDto(round), nil
+}
+
+func (s *gameServiceImpl) GetHistory(userId string, game string, limit int, offset int) ([]model.GameRoundDto, error) {
+	rounds, err := s.repo.FindRoundsByUserId(userId, game, limit, offset)
+	if err != nil {
+		s.log.Named("GetHistory").Error("Failed to get rounds", zap.Error(err), zap.String("game", game))
+		return nil, err
+	}
+
+	roundDtos := make([]model.GameRoundDto, len(rounds))
+	for i := range rounds {
+		roundDtos[i] = *roundToDto(&rounds[i])
+	}
+	return roundDtos, nil
+}
+
+func (s *gameServiceImpl) GetStats(userId string, game string) (*model.GameStatsDto, error) {
+	stats, err := s.repo.GetStatsByUserId(userId, game)
+	if err != nil {
+		s.log.Named("GetStats").Error("Failed to get stats", zap.Error(err), zap.String("game", game))
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *gameServiceImpl) GetSeed(userId string, game string) (*model.GameSeedDto, error) {
+	seed, err := s.getActiveSeed(userId, game)
+	if err != nil {
+		s.log.Named("GetSeed").Error("Failed to get active seed", zap.Error(err), zap.String("game", game))
+		return nil, err
+	}
+
+	return seedToDto(seed), nil
+}
+
+func (s *gameServiceImpl) RotateSeed(userId string, game string, clientSeed string) (*model.RotateGameSeedResponse, error) {
+	if len(clientSeed) > 64 {
+		return nil, errors.New("client seed cannot be longer than 64 characters")
+	}
+
+	next, err := newSeed(userId, game, clientSeed)
+	if err != nil {
+		s.log.Named("RotateSeed").Error("Failed to generate seed", zap.Error(err))
+		return nil, err
+	}
+
+	previous, err := s.repo.RotateSeed(userId, game, next)
+	if err != nil {
+		s.log.Named("RotateSeed").Error("Failed to rotate seed", zap.Error(err), zap.String("game", game))
+		return nil, err
+	}
+
+	res := &model.RotateGameSeedResponse{Current: *seedToDto(next)}
+	if previous != nil {
+		res.Previous = &model.RevealedGameSeedDto{
+			ServerSeed:     previous.ServerSeed,
+			ServerSeedHash: previous.ServerSeedHash,
+			ClientSeed:     previous.ClientSeed,
+			RoundCount:     previous.Nonce,
+			RevealedAt:     previous.RevealedAt,
+		}
+	}
+
+	s.log.Named("RotateSeed").Info("Rotated seed", zap.String("userId", userId), zap.String("game", game))
+	return res, nil
+}
+
+// getActiveSeed returns the user's active seed for game, committing a new one on their first round
+func (s *gameServiceImpl) getActiveSeed(userId string, game string) (*model.GameSeed, error) {
+	seed, err := s.repo.FindActiveSeed(userId, game)
+	if err == nil {
+		return seed, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	seed, err = newSeed(userId, game, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateSeed(seed); err != nil {
+		// a concurrent request may have committed one first
+		return s.repo.FindActiveSeed(userId, game)
+	}
+	return seed, nil
+}
+
+func newSeed(userId string, game string, clientSeed string) (*model.GameSeed, error) {
+	serverSeed, err := utils.GenerateServerSeed()
+	if err != nil {
+		return nil, err
+	}
+	if clientSeed == "" {
+		if clientSeed, err = utils.GenerateClientSeed(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.GameSeed{
+		Id:             uuid.NewString(),
+		UserId:         userId,
+		Game:           game,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: utils.HashServerSeed(serverSeed),
+		ClientSeed:     clientSeed,
+		IsActive:       true,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
+func seedToDto(seed *model.GameSeed) *model.GameSeedDto {
+	return &model.GameSeedDto{
+		Game:           seed.Game,
+		ServerSeedHash: seed.ServerSeedHash,
+		ClientSeed:     seed.ClientSeed,
+		Nonce:          seed.Nonce,
+		CreatedAt:      seed.CreatedAt,
+	}
+}
+
+// roundToDto only shows the server seed once the seed the round was played with has been rotated
+func roundToDto(round *model.GameRound) *model.GameRoundDto {
+	serverSeed := round.ServerSeed
+	if round.Seed != nil && !round.Seed.IsActive {
+		serverSeed = round.Seed.ServerSeed
+	}
+
+	return &model.GameRoundDto{
+		Id:             round.Id,
+		Game:           round.Game,
+		BetAmount:      round.BetAmount,
+		Choice:         round.Choice,
+		Result:         round.Result,
+		Won:            round.Won,
+		Multiplier:     round.Multiplier,
+		Payout:         round.Payout,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: round.ServerSeedHash,
+		ClientSeed:     round.ClientSeed,
+		Nonce:          round.Nonce,
+		CreatedAt:      round.CreatedAt,
+	}
+}
@@ -0,0 +1,97 @@
+package stakegame
+
+import (
+	"errors"
+	"math"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Games recorded as rounds by the framework
+const (
+	GameCoinflip = "coinflip"
+	GameDice     = "dice"
+)
+
+// Bet limits shared by every stake game
+const (
+	MinBetAmount = 1.0
+	MaxBetAmount = 1000000.0
+)
+
+// ValidateBetAmount checks a bet against the limits shared by every stake game
+func ValidateBetAmount(amount float64) error {
+	if amount < MinBetAmount {
+		return errors.New("bet amount must be at least 1 coin")
+	}
+	if amount > MaxBetAmount {
+		return errors.New("bet amount cannot exceed 1,000,000 coins")
+	}
+	return nil
+}
+
+// LockUser loads the user FOR UPDATE so balance checks and changes in tx cannot race
+func LockUser(tx *gorm.DB, userId string) (*model.User, error) {
+	var user model.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", userId).
+		First(&user).Error; err != nil {
+		return nil, errors.New("user not found")
+	}
+	return &user, nil
+}
+
+// useSeed locks the user's active seed for game and bumps its nonce, returning the seed with the nonce to roll with
+func useSeed(tx *gorm.DB, userId string, game string) (*model.GameSeed, error) {
+	var seed model.GameSeed
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND game = ? AND is_active = ?", userId, game, true).
+		First(&seed).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&model.GameSeed{}).Where("id = ?", seed.Id).
+		Update("nonce", gorm.Expr("nonce + 1")).Error; err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+// Debit takes a wager from a user locked with LockUser
+func Debit(tx *gorm.DB, user *model.User, amount float64) error {
+	if user.RemainingCoin < amount {
+		return errors.New("insufficient balance")
+	}
+
+	if err := tx.Model(&model.User{}).
+		Where("id = ?", user.Id).
+		Update("remaining_coin", gorm.Expr("remaining_coin - ?", amount)).
+		Error; err != nil {
+		return errors.New("failed to deduct balance")
+	}
+
+	user.RemainingCoin -= amount
+	return nil
+}
+
+// Credit pays amount to a user, nothing is written when amount is zero
+func Credit(tx *gorm.DB, userId string, amount float64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	if err := tx.Model(&model.User{}).
+		Where("id = ?", userId).
+		Update("remaining_coin", gorm.Expr("remaining_coin + ?", amount)).
+		Error; err != nil {
+		return errors.New("failed to credit winnings")
+	}
+	return nil
+}
+
+// CalculatePayout returns the bet times the multiplier, floored to the coin precision
+func CalculatePayout(betAmount float64, multiplier float64) float64 {
+	return math.Floor(betAmount*multiplier*100) / 100
+}
@@ -2,16 +2,21 @@
 package stakemine
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/cache"
 	"gorm.io/gorm"
 )
 
 type stakeMineRepositoryImpl struct {
-	db *gorm.DB
+	db    *gorm.DB
+	cache cache.RedisClient
 }
 
-func NewStakeMineRepository(db *gorm.DB) StakeMineRepository {
-	return &stakeMineRepositoryImpl{db: db}
+func NewStakeMineRepository(db *gorm.DB, cache cache.RedisClient) StakeMineRepository {
+	return &stakeMineRepositoryImpl{db: db, cache: cache}
 }
 
 func (r *stakeMineRepositoryImpl) Create(game *model.MineGame) error {
@@ -43,6 +48,7 @@ func (r *stakeMineRepositoryImpl) FindActiveByUserId(userId string) (*model.Mine
 func (r *stakeMineRepositoryImpl) FindByUserId(userId string, limit int, offset int) ([]model.MineGame, error) {
 	var games []model.MineGame
 	err := r.db.Where("user_id = ?", userId).
+		Where(model.InActiveSeason("season_id")).
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -57,28 +63,124 @@ func (r *stakeMineRepositoryImpl) CreateHistory(history *model.MineGameHistory)
 	return r.db.Create(history).Error
 }
 
+func (r *stakeMineRepositoryImpl) FindHistoryByGameId(gameId string) ([]model.MineGameHistory, error) {
+	var history []model.MineGameHistory
+	err := r.db.Where("game_id = ?", gameId).
+		Order("step ASC, created_at ASC").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (r *stakeMineRepositoryImpl) FindPendingSeedByUserId(userId string) (*model.MineServerSeed, error) {
+	var seed model.MineServerSeed
+	err := r.db.Where("user_id = ? AND game_id IS NULL", userId).
+		Order("created_at DESC").
+		First(&seed).Error
+	if err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+func (r *stakeMineRepositoryImpl) CreateSeed(seed *model.MineServerSeed) error {
+	return r.db.Create(seed).Error
+}
+
+func (r *stakeMineRepositoryImpl) FindIdleActiveGameIds(idleSince time.Time, limit int) ([]string, error) {
+	var ids []string
+	err := r.db.Model(&model.MineGame{}).
+		Where("status = ? AND updated_at < ?", "active", idleSince).
+		Order("updated_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetLeaderboard ranks players by the given board over the active season's games finished since the given time
+func (r *stakeMineRepositoryImpl) GetLeaderboard(board string, since *time.Time, colorId string, limit int) ([]model.MineLeaderboardEntryDto, error) {
+	statuses := []string{"won", "cashed_out", "lost", "expired"}
+
+	var value string
+	switch board {
+	case LeaderboardBiggestCashout:
+		value = "MAX(mine_games.current_payout)"
+		statuses = []string{"won", "cashed_out"}
+	case LeaderboardHighestMultiplier:
+		value = "MAX(mine_games.multiplier)"
+	case LeaderboardNetProfit:
+		value = "SUM(CASE WHEN mine_games.status IN ('won', 'cashed_out') THEN mine_games.current_payout ELSE 0 END - mine_games.bet_amount)"
+	case LeaderboardLongestStreak:
+		value = "MAX(CASE WHEN mine_games.status = 'lost' THEN mine_games.revealed_count - 1 ELSE mine_games.revealed_count END)"
+	default:
+		return nil, fmt.Errorf("unknown leaderboard %q", board)
+	}
+
+	query := r.db.Table("mine_games").
+		Select("users.id AS user_id, users.name, users.nick_name, intania_groups.color_id, "+value+" AS value").
+		Joins("JOIN users ON users.id = mine_games.user_id").
+		Joins("LEFT JOIN intania_groups ON intania_groups.id = users.group_id").
+		Joins("LEFT JOIN colors ON colors.id = intania_groups.color_id").
+		Where("mine_games.status IN ?", statuses).
+		Where(model.InActiveSeason("mine_games.season_id")).
+		Group("users.id, users.name, users.nick_name, intania_groups.color_id").
+		Order("value DESC").
+		Limit(limit)
+
+	if since != nil {
+		query = query.Where("mine_games.completed_at >= ?", *since)
+	}
+	if colorId != "" {
+		query = query.Where("colors.id = ?", colorId)
+	}
+
+	var entries []model.MineLeaderboardEntryDto
+	if err := query.Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *stakeMineRepositoryImpl) GetLeaderboardCache(key string, value interface{}) error {
+	return r.cache.GetValue(key, value)
+}
+
+func (r *stakeMineRepositoryImpl) SetLeaderboardCache(key string, value interface{}, ttl int) error {
+	return r.cache.SetValue(key, value, ttl)
+}
+
+// GetStatsByUserId sums up the user's games of the active season
 func (r *stakeMineRepositoryImpl) GetStatsByUserId(userId string) (*model.MineGameStatsDto, error) {
 	var stats model.MineGameStatsDto
 
+	userGames := func() *gorm.DB {
+		return r.db.Model(&model.MineGame{}).Where(model.InActiveSeason("season_id"))
+	}
+
 	// Count games by status
 	var gamesWon, gamesLost, gamesCashedOut int64
-	r.db.Model(&model.MineGame{}).Where("user_id = ? AND status = ?", userId, "won").Count(&gamesWon)
-	r.db.Model(&model.MineGame{}).Where("user_id = ? AND status = ?", userId, "lost").Count(&gamesLost)
-	r.db.Model(&model.MineGame{}).Where("user_id = ? AND status = ?", userId, "cashed_out").Count(&gamesCashedOut)
+	userGames().Where("user_id = ? AND status = ?", userId, "won").Count(&gamesWon)
+	userGames().Where("user_id = ? AND status IN ?", userId, []string{"lost", "expired"}).Count(&gamesLost)
+	userGames().Where("user_id = ? AND status = ?", userId, "cashed_out").Count(&gamesCashedOut)
 
 	stats.GamesWon = int(gamesWon)
 	stats.GamesLost = int(gamesLost)
 	stats.GamesCashedOut = int(gamesCashedOut)
 	stats.TotalGames = stats.GamesWon + stats.GamesLost + stats.GamesCashedOut
 
-	// Calculate total wagered
-	r.db.Model(&model.MineGame{}).
-		Where("user_id = ?", userId).
+	// Calculate total wagered, refunded games never took the bet
+	userGames().
+		Where("user_id = ? AND status <> ?", userId, "refunded").
 		Select("COALESCE(SUM(bet_amount), 0)").
 		Scan(&stats.TotalWagered)
 
 	// Calculate total winnings (won + cashed out games only)
-	r.db.Model(&model.MineGame{}).
+	userGames().
 		Where("user_id = ? AND status IN ?", userId, []string{"won", "cashed_out"}).
 		Select("COALESCE(SUM(current_payout), 0)").
 		Scan(&stats.TotalWinnings)
@@ -26,11 +26,15 @@ func (h *StakeMineHttpHandler) RegisterRoutes(router fiber.Router, mid *middlewa
 	router.Get("/active", h.GetActiveGame)
 	router.Get("/history", h.GetHistory)
 	router.Get("/stats", h.GetStats)
+	router.Get("/seed", h.GetSeed)
+	router.Get("/leaderboard", h.GetLeaderboard)
+	router.Get("/:id/verify", h.VerifyGame)
+	router.Get("/:id/replay", h.GetReplay)
 	router.Get("/:id", h.GetGame)
 }
 
 // @Summary Create a new Stake Mines game
-// @Description Start a new Stake Mines game with specified bet amount and risk level
+// @Description Start a new Stake Mines game with specified bet amount, board and optional auto cash-out target
 // @Tags StakeMines
 // @Accept json
 // @Produce json
@@ -61,7 +65,7 @@ func (h *StakeMineHttpHandler) CreateGame(c *fiber.Ctx) error {
 }
 
 // @Summary Reveal a tile in the game
-// @Description Reveal a specific tile in an active Stake Mines game
+// @Description Reveal a tile, or a batch of tiles in order via indices, in an active Stake Mines game. Stops on a bomb or auto cash-out
 // @Tags StakeMines
 // @Accept json
 // @Produce json
@@ -187,8 +191,11 @@ func (h *StakeMineHttpHandler) GetHistory(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit", "20"))
 	offset, _ := strconv.Atoi(c.Query("offset", "0"))
 
-	if limit > 100 {
-		limit = 100
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
 	}
 
 	history, err := h.service.GetGameHistory(profile.Id, limit, offset)
@@ -226,3 +233,102 @@ func (h *StakeMineHttpHandler) GetStats(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(stats)
 }
+
+// @Summary Get the next server seed hash
+// @Description Get the SHA-256 hash of the server seed committed for the user's next Stake Mines game
+// @Tags StakeMines
+// @Produce json
+// @Success 200 {object} model.MineSeedDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /mines/seed [get]
+// @Security BearerAuth
+func (h *StakeMineHttpHandler) GetSeed(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	seed, err := h.service.GetSeed(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seed)
+}
+
+// @Summary Verify a finished game
+// @Description Recompute a finished Stake Mines game's grid from its revealed server seed, client seed and nonce
+// @Tags StakeMines
+// @Produce json
+// @Param id path string true "Game ID"
+// @Success 200 {object} model.MineGameVerifyDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /mines/{id}/verify [get]
+// @Security BearerAuth
+func (h *StakeMineHttpHandler) VerifyGame(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+	gameId := c.Params("id")
+
+	result, err := h.service.VerifyGame(profile.Id, gameId)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// @Summary Replay a game
+// @Description Get the ordered reveals of a Stake Mines game with the multiplier and payout after each step, plus the final grid once finished
+// @Tags StakeMines
+// @Produce json
+// @Param id path string true "Game ID"
+// @Success 200 {object} model.MineGameReplayDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /mines/{id}/replay [get]
+// @Security BearerAuth
+func (h *StakeMineHttpHandler) GetReplay(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+	gameId := c.Params("id")
+
+	replay, err := h.service.GetReplay(profile.Id, gameId)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(replay)
+}
+
+// @Summary Get mines leaderboard
+// @Description Get a global or per-color Stake Mines leaderboard for a period
+// @Tags StakeMines
+// @Produce json
+// @Param type query string true "Leaderboard type" Enums(biggest_cashout, highest_multiplier, net_profit, longest_streak)
+// @Param period query string false "Period" Enums(day, week, month, all) default(all)
+// @Param color query string false "Color ID"
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} model.MineLeaderboardDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /mines/leaderboard [get]
+// @Security BearerAuth
+func (h *StakeMineHttpHandler) GetLeaderboard(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	leaderboard, err := h.service.GetLeaderboard(c.Query("type"), c.Query("period", "all"), c.Query("color"), limit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(leaderboard)
+}
@@ -1,6 +1,9 @@
 package stakemine
 
 import (
+	"context"
+	"time"
+
 	"github.com/esc-chula/intania-888-backend/internal/model"
 )
 
@@ -12,6 +15,12 @@ type StakeMineService interface {
 	GetActiveGame(userId string) (*model.MineGameDto, error)
 	GetGameHistory(userId string, limit int, offset int) ([]model.MineGameHistoryDto, error)
 	GetStats(userId string) (*model.MineGameStatsDto, error)
+	GetSeed(userId string) (*model.MineSeedDto, error)
+	VerifyGame(userId string, gameId string) (*model.MineGameVerifyDto, error)
+	GetReplay(userId string, gameId string) (*model.MineGameReplayDto, error)
+	GetLeaderboard(board string, period string, colorId string, limit int) (*model.MineLeaderboardDto, error)
+	RunIdleGameSweeper(ctx context.Context)
+	SweepIdleGames() (int, error)
 }
 
 type StakeMineRepository interface {
@@ -21,5 +30,12 @@ type StakeMineRepository interface {
 	FindActiveByUserId(userId string) (*model.MineGame, error)
 	FindByUserId(userId string, limit int, offset int) ([]model.MineGame, error)
 	CreateHistory(history *model.MineGameHistory) error
+	FindHistoryByGameId(gameId string) ([]model.MineGameHistory, error)
 	GetStatsByUserId(userId string) (*model.MineGameStatsDto, error)
+	FindPendingSeedByUserId(userId string) (*model.MineServerSeed, error)
+	CreateSeed(seed *model.MineServerSeed) error
+	FindIdleActiveGameIds(idleSince time.Time, limit int) ([]string, error)
+	GetLeaderboard(board string, since *time.Time, colorId string, limit int) ([]model.MineLeaderboardEntryDto, error)
+	GetLeaderboardCache(key string, value interface{}) error
+	SetLeaderboardCache(key string, value interface{}, ttl int) error
 }
@@ -1,54 +1,112 @@
 package stakemine
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/esc-chula/intania-888-backend/internal/domain/notification"
+	"github.com/esc-chula/intania-888-backend/internal/domain/quest"
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
 	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"github.com/esc-chula/intania-888-backend/utils"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// idleSweepBatchSize is the most idle games settled in a single sweep
+const idleSweepBatchSize = 100
+
 type stakeMineServiceImpl struct {
-	repo   StakeMineRepository
-	userDB *gorm.DB
-	log    *zap.Logger
+	repo            StakeMineRepository
+	userDB          *gorm.DB
+	notificationSvc notification.NotificationService
+	questTracker    quest.QuestTracker
+	cfg             config.Config
+	log             *zap.Logger
 }
 
-func NewStakeMineService(repo StakeMineRepository, db *gorm.DB, log *zap.Logger) StakeMineService {
+func NewStakeMineService(repo StakeMineRepository, db *gorm.DB, notificationSvc notification.NotificationService, questTracker quest.QuestTracker, cfg config.Config, log *zap.Logger) StakeMineService {
 	return &stakeMineServiceImpl{
-		repo:   repo,
-		userDB: db,
-		log:    log,
+		repo:            repo,
+		userDB:          db,
+		notificationSvc: notificationSvc,
+		questTracker:    questTracker,
+		cfg:             cfg,
+		log:             log,
 	}
 }
 
 func (s *stakeMineServiceImpl) CreateGame(userId string, req *model.CreateMineGameRequest) (*model.MineGameDto, error) {
-	if req.BetAmount < 1 {
-		return nil, errors.New("bet amount must be at least 1 coin")
-	}
-	if req.BetAmount > 1000000 {
-		return nil, errors.New("bet amount cannot exceed 1,000,000 coins")
+	if err := stakegame.ValidateBetAmount(req.BetAmount); err != nil {
+		return nil, err
 	}
 
-	// Validate risk level
-	if !ValidateRiskLevel(req.RiskLevel) {
+	// Validate risk level, which is optional when a bomb count is given
+	if req.RiskLevel != "" && !ValidateRiskLevel(req.RiskLevel) {
 		s.log.Named("CreateGame").Error("Invalid risk level", zap.String("risk", req.RiskLevel))
 		return nil, errors.New("invalid risk level. must be 'low', 'medium', or 'high'")
 	}
+	if req.RiskLevel == "" && req.BombCount == 0 {
+		return nil, errors.New("either risk level or bomb count is required")
+	}
+
+	gridSize := req.GridSize
+	if gridSize == 0 {
+		gridSize = DefaultGridSize
+	}
+	tileCount := gridSize * gridSize
+
+	riskLevel := req.RiskLevel
+	bombCount := req.BombCount
+	if bombCount == 0 {
+		bombCount = GetBombCountForGrid(riskLevel, tileCount)
+	} else if riskLevel == "" || bombCount != GetBombCountForGrid(riskLevel, tileCount) {
+		riskLevel = "custom"
+	}
+
+	if err := ValidateBoard(gridSize, bombCount); err != nil {
+		return nil, err
+	}
+
+	houseEdge := s.cfg.GetGame().MinesHouseEdge
+	maxDiamonds := GetMaxDiamonds(tileCount, bombCount)
+	if req.AutoCashoutDiamonds < 0 || req.AutoCashoutDiamonds > maxDiamonds {
+		return nil, fmt.Errorf("auto cash-out diamonds must be between 0 and %d (0 disables)", maxDiamonds)
+	}
+	if req.AutoCashoutMultiplier < 0 || (req.AutoCashoutMultiplier > 0 && req.AutoCashoutMultiplier <= 1) {
+		return nil, errors.New("auto cash-out multiplier must be greater than 1")
+	}
+	if req.AutoCashoutMultiplier > CalculateMultiplier(maxDiamonds, tileCount, bombCount, houseEdge) {
+		return nil, errors.New("auto cash-out multiplier can never be reached on this board")
+	}
+
+	if req.Nonce < 0 {
+		return nil, errors.New("nonce cannot be negative")
+	}
+
+	clientSeed := req.ClientSeed
+	if clientSeed == "" {
+		generated, err := utils.GenerateClientSeed()
+		if err != nil {
+			s.log.Named("CreateGame").Error("Failed to generate client seed", zap.Error(err))
+			return nil, errors.New("failed to generate client seed")
+		}
+		clientSeed = generated
+	}
 
 	var game *model.MineGame
 
 	err := s.userDB.Transaction(func(tx *gorm.DB) error {
-		var user model.User
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("id = ?", userId).
-			First(&user).Error; err != nil {
+		user, err := stakegame.LockUser(tx, userId)
+		if err != nil {
 			s.log.Named("CreateGame").Error("User not found", zap.Error(err))
-			return errors.New("user not found")
+			return err
 		}
 
 		var activeGameCount int64
@@ -71,7 +129,29 @@ func (s *stakeMineServiceImpl) CreateGame(userId string, req *model.CreateMineGa
 			return errors.New("insufficient balance")
 		}
 
-		grid, err := GenerateGrid(req.RiskLevel)
+		// Use the server seed the player was shown before betting, or commit a fresh one
+		var seed model.MineServerSeed
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND game_id IS NULL", userId).
+			Order("created_at DESC").
+			First(&seed).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			newSeed, err := newServerSeed(userId)
+			if err != nil {
+				s.log.Named("CreateGame").Error("Failed to generate server seed", zap.Error(err))
+				return errors.New("failed to generate server seed")
+			}
+			if err := tx.Create(newSeed).Error; err != nil {
+				s.log.Named("CreateGame").Error("Failed to save server seed", zap.Error(err))
+				return errors.New("failed to generate server seed")
+			}
+			seed = *newSeed
+		} else if err != nil {
+			s.log.Named("CreateGame").Error("Failed to load server seed", zap.Error(err))
+			return errors.New("failed to load server seed")
+		}
+
+		grid, err := GenerateFairGrid(seed.ServerSeed, clientSeed, req.Nonce, tileCount, bombCount)
 		if err != nil {
 			s.log.Named("CreateGame").Error("Failed to generate grid", zap.Error(err))
 			return errors.New("failed to generate game grid")
@@ -84,17 +164,26 @@ func (s *stakeMineServiceImpl) CreateGame(userId string, req *model.CreateMineGa
 		}
 
 		game = &model.MineGame{
-			Id:            uuid.New().String(),
-			UserId:        userId,
-			BetAmount:     req.BetAmount,
-			RiskLevel:     req.RiskLevel,
-			Status:        "active",
-			RevealedCount: 0,
-			CurrentPayout: req.BetAmount,
-			Multiplier:    1.0,
-			GridData:      gridJSON,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
+			Id:                    uuid.New().String(),
+			UserId:                userId,
+			BetAmount:             req.BetAmount,
+			RiskLevel:             riskLevel,
+			GridSize:              gridSize,
+			BombCount:             bombCount,
+			HouseEdge:             houseEdge,
+			Status:                "active",
+			RevealedCount:         0,
+			CurrentPayout:         req.BetAmount,
+			Multiplier:            1.0,
+			GridData:              gridJSON,
+			ServerSeed:            seed.ServerSeed,
+			ServerSeedHash:        seed.ServerSeedHash,
+			ClientSeed:            clientSeed,
+			Nonce:                 req.Nonce,
+			CreatedAt:             time.Now(),
+			AutoCashoutMultiplier: req.AutoCashoutMultiplier,
+			AutoCashoutDiamonds:   req.AutoCashoutDiamonds,
+			UpdatedAt:             time.Now(),
 		}
 
 		if err := tx.Create(game).Error; err != nil {
@@ -102,12 +191,16 @@ func (s *stakeMineServiceImpl) CreateGame(userId string, req *model.CreateMineGa
 			return errors.New("failed to create game")
 		}
 
-		if err := tx.Model(&model.User{}).
-			Where("id = ?", userId).
-			Update("remaining_coin", gorm.Expr("remaining_coin - ?", req.BetAmount)).
-			Error; err != nil {
+		if err := tx.Model(&model.MineServerSeed{}).
+			Where("id = ?", seed.Id).
+			Update("game_id", game.Id).Error; err != nil {
+			s.log.Named("CreateGame").Error("Failed to use server seed", zap.Error(err))
+			return errors.New("failed to create game")
+		}
+
+		if err := stakegame.Debit(tx, user, req.BetAmount); err != nil {
 			s.log.Named("CreateGame").Error("Failed to deduct balance", zap.Error(err))
-			return errors.New("failed to deduct balance")
+			return err
 		}
 
 		return nil
@@ -125,152 +218,145 @@ func (s *stakeMineServiceImpl) CreateGame(userId string, req *model.CreateMineGa
 }
 
 func (s *stakeMineServiceImpl) RevealTile(userId string, gameId string, req *model.RevealMineTileRequest) (*model.MineGameDto, string, error) {
-	// Get game
-	game, err := s.repo.FindById(gameId)
-	if err != nil {
-		s.log.Named("RevealTile").Error("Game not found", zap.Error(err))
-		return nil, "", errors.New("game not found")
-	}
-
-	// Verify ownership
-	if game.UserId != userId {
-		s.log.Named("RevealTile").Warn("Unauthorized access attempt", zap.String("userId", userId), zap.String("gameId", gameId))
-		return nil, "", errors.New("unauthorized: this is not your game")
-	}
-
-	// Check game status
-	if game.Status != "active" {
-		return nil, "", errors.New("game is not active")
+	indices := req.Indices
+	if len(indices) == 0 {
+		indices = []int{req.Index}
 	}
 
-	// Validate tile index
-	if !ValidateTileIndex(req.Index) {
-		return nil, "", errors.New("invalid tile index")
-	}
-
-	// Parse grid
-	grid, err := JSONToGrid(game.GridData)
-	if err != nil {
-		s.log.Named("RevealTile").Error("Failed to parse grid", zap.Error(err))
-		return nil, "", errors.New("failed to load game data")
-	}
-
-	// Check if tile already revealed
-	if grid[req.Index].Revealed {
-		return nil, "", errors.New("tile already revealed")
-	}
-
-	// Reveal the tile
-	grid[req.Index].Revealed = true
-	game.RevealedCount++
-
+	var game *model.MineGame
 	var message string
-	var needsBalanceUpdate bool
 
-	if grid[req.Index].Type == "bomb" {
-		// Hit a bomb - game over
-		game.Status = "lost"
-		game.CurrentPayout = 0
-		now := time.Now()
-		game.CompletedAt = &now
-
-		// Reveal all tiles
-		for i := range grid {
-			grid[i].Revealed = true
+	err := s.userDB.Transaction(func(tx *gorm.DB) error {
+		// Lock the game so concurrent reveals and cash-outs are applied one at a time
+		var err error
+		game, err = s.findGameForUpdate(tx, userId, gameId, "RevealTile")
+		if err != nil {
+			return err
 		}
 
-		message = "💣 BOOM! You hit a bomb and lost!"
-		s.log.Named("RevealTile").Info("Player hit bomb", zap.String("gameId", gameId), zap.String("userId", userId))
-	} else {
-		// Found a diamond
-		game.Multiplier = CalculateMultiplier(game.RevealedCount, game.RiskLevel)
+		// Check game status
+		if game.Status != "active" {
+			return errors.New("game is not active")
+		}
 
-		// Calculate payout safely with overflow protection
-		payout, err := CalculatePayoutSafe(game.BetAmount, game.Multiplier)
+		// Parse grid
+		grid, err := JSONToGrid(game.GridData)
 		if err != nil {
-			s.log.Named("RevealTile").Error("Payout calculation error", zap.Error(err))
-			return nil, "", errors.New("payout calculation failed")
+			s.log.Named("RevealTile").Error("Failed to parse grid", zap.Error(err))
+			return errors.New("failed to load game data")
 		}
-		game.CurrentPayout = payout
 
-		// Check if all safe tiles revealed (auto win)
-		totalTiles := 16
-		bombs := GetBombCount(game.RiskLevel)
-		if game.RevealedCount == totalTiles-bombs {
-			game.Status = "won"
-			now := time.Now()
-			game.CompletedAt = &now
-			needsBalanceUpdate = true
-
-			// Reveal all tiles
-			for i := range grid {
-				grid[i].Revealed = true
+		// Validate every index before revealing anything
+		requested := make(map[int]bool, len(indices))
+		for _, index := range indices {
+			if !ValidateTileIndex(index, len(grid)) {
+				return errors.New("invalid tile index")
 			}
-
-			message = fmt.Sprintf("🎉 Perfect! You found all diamonds! Won: %.2f coins", game.CurrentPayout)
-			s.log.Named("RevealTile").Info("Player won game", zap.String("gameId", gameId), zap.String("userId", userId), zap.Float64("payout", game.CurrentPayout))
-		} else {
-			message = fmt.Sprintf("💎 Diamond found! Current payout: %.2f coins (%.2fx)", game.CurrentPayout, game.Multiplier)
-		}
-	}
-
-	gridJSON, _ := GridToJSON(grid)
-	game.GridData = gridJSON
-	game.UpdatedAt = time.Now()
-
-	if needsBalanceUpdate {
-		err := s.userDB.Transaction(func(tx *gorm.DB) error {
-			if err := tx.Save(game).Error; err != nil {
-				s.log.Named("RevealTile").Error("Failed to update game", zap.Error(err))
-				return errors.New("failed to update game")
+			if grid[index].Revealed || requested[index] {
+				return errors.New("tile already revealed")
 			}
+			requested[index] = true
+		}
 
-			if err := tx.Model(&model.User{}).
-				Where("id = ?", userId).
-				Update("remaining_coin", gorm.Expr("remaining_coin + ?", game.CurrentPayout)).
-				Error; err != nil {
-				s.log.Named("RevealTile").Error("Failed to credit winnings", zap.Error(err))
-				return errors.New("failed to credit winnings")
+		bombCount, houseEdge := s.gameBoard(game)
+
+		// Reveal tiles in order until the game ends
+		for _, index := range indices {
+			grid[index].Revealed = true
+			game.RevealedCount++
+
+			if grid[index].Type == "bomb" {
+				// Hit a bomb - game over
+				game.Status = "lost"
+				game.CompletionReason = "bomb"
+				game.CurrentPayout = 0
+
+				message = "💣 BOOM! You hit a bomb and lost!"
+				s.log.Named("RevealTile").Info("Player hit bomb", zap.String("gameId", gameId), zap.String("userId", userId))
+			} else {
+				// Found a diamond
+				game.Multiplier = gameMultiplier(game, len(grid), bombCount, houseEdge)
+
+				// Calculate payout safely with overflow protection
+				payout, err := CalculatePayoutSafe(game.BetAmount, game.Multiplier)
+				if err != nil {
+					s.log.Named("RevealTile").Error("Payout calculation error", zap.Error(err))
+					return errors.New("payout calculation failed")
+				}
+				game.CurrentPayout = payout
+
+				if game.RevealedCount == GetMaxDiamonds(len(grid), bombCount) {
+					// All safe tiles revealed (auto win)
+					game.Status = "won"
+					game.CompletionReason = "all_diamonds"
+
+					message = fmt.Sprintf("🎉 Perfect! You found all diamonds! Won: %.2f coins", game.CurrentPayout)
+					s.log.Named("RevealTile").Info("Player won game", zap.String("gameId", gameId), zap.String("userId", userId), zap.Float64("payout", game.CurrentPayout))
+				} else if autoCashoutReached(game) {
+					game.Status = "cashed_out"
+					game.CompletionReason = "auto_cashout"
+
+					message = fmt.Sprintf("🤖 Auto cash-out at %.2fx! Won: %.2f coins", game.Multiplier, game.CurrentPayout)
+					s.log.Named("RevealTile").Info("Player auto cashed out", zap.String("gameId", gameId), zap.String("userId", userId), zap.Float64("payout", game.CurrentPayout))
+				} else {
+					message = fmt.Sprintf("💎 Diamond found! Current payout: %.2f coins (%.2fx)", game.CurrentPayout, game.Multiplier)
+				}
 			}
 
-			history := &model.MineGameHistory{
+			if err := tx.Create(&model.MineGameHistory{
 				Id:          uuid.New().String(),
 				GameId:      game.Id,
-				TileIndex:   req.Index,
-				TileType:    "diamond",
+				Step:        game.RevealedCount,
+				TileIndex:   index,
+				TileType:    grid[index].Type,
 				Multiplier:  game.Multiplier,
 				PayoutAtHit: game.CurrentPayout,
 				CreatedAt:   time.Now(),
-			}
-			if err := tx.Create(history).Error; err != nil {
+			}).Error; err != nil {
 				s.log.Named("RevealTile").Error("Failed to create history", zap.Error(err))
+				return errors.New("failed to update game")
 			}
 
-			return nil
-		})
+			if game.Status != "active" {
+				break
+			}
+		}
 
-		if err != nil {
-			return nil, "", err
+		if game.Status != "active" {
+			now := time.Now()
+			game.CompletedAt = &now
+
+			// Reveal all tiles
+			for i := range grid {
+				grid[i].Revealed = true
+			}
 		}
-	} else {
-		if err := s.repo.Update(game); err != nil {
+
+		gridJSON, _ := GridToJSON(grid)
+		game.GridData = gridJSON
+		game.UpdatedAt = time.Now()
+
+		if err := tx.Save(game).Error; err != nil {
 			s.log.Named("RevealTile").Error("Failed to update game", zap.Error(err))
-			return nil, "", errors.New("failed to update game")
+			return errors.New("failed to update game")
 		}
 
-		tileType := "diamond"
-		if grid[req.Index].Type == "bomb" {
-			tileType = "bomb"
+		if game.Status == "won" || game.Status == "cashed_out" {
+			if err := stakegame.Credit(tx, userId, game.CurrentPayout); err != nil {
+				s.log.Named("RevealTile").Error("Failed to credit winnings", zap.Error(err))
+				return err
+			}
 		}
-		s.repo.CreateHistory(&model.MineGameHistory{
-			Id:          uuid.New().String(),
-			GameId:      game.Id,
-			TileIndex:   req.Index,
-			TileType:    tileType,
-			Multiplier:  game.Multiplier,
-			PayoutAtHit: game.CurrentPayout,
-			CreatedAt:   time.Now(),
-		})
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if game.Status == "won" || game.Status == "cashed_out" {
+		s.trackCashOut(game)
 	}
 
 	gameDto, _ := s.gameToDto(game, game.Status == "active")
@@ -278,55 +364,49 @@ func (s *stakeMineServiceImpl) RevealTile(userId string, gameId string, req *mod
 }
 
 func (s *stakeMineServiceImpl) CashOut(userId string, gameId string) (*model.MineGameDto, error) {
-	// Get game
-	game, err := s.repo.FindById(gameId)
-	if err != nil {
-		s.log.Named("CashOut").Error("Game not found", zap.Error(err))
-		return nil, errors.New("game not found")
-	}
+	var game *model.MineGame
 
-	// Verify ownership
-	if game.UserId != userId {
-		s.log.Named("CashOut").Warn("Unauthorized cash out attempt", zap.String("userId", userId), zap.String("gameId", gameId))
-		return nil, errors.New("unauthorized: this is not your game")
-	}
+	err := s.userDB.Transaction(func(tx *gorm.DB) error {
+		// Lock the game so it cannot be cashed out twice or after a bomb
+		var err error
+		game, err = s.findGameForUpdate(tx, userId, gameId, "CashOut")
+		if err != nil {
+			return err
+		}
 
-	// Check game status
-	if game.Status != "active" {
-		return nil, errors.New("cannot cash out - game is not active")
-	}
+		// Check game status
+		if game.Status != "active" {
+			return errors.New("cannot cash out - game is not active")
+		}
 
-	// Must reveal at least one tile
-	if game.RevealedCount == 0 {
-		return nil, errors.New("cannot cash out without revealing any tiles")
-	}
+		// Must reveal at least one tile
+		if game.RevealedCount == 0 {
+			return errors.New("cannot cash out without revealing any tiles")
+		}
 
-	// Update game status
-	game.Status = "cashed_out"
-	now := time.Now()
-	game.CompletedAt = &now
+		// Update game status
+		game.Status = "cashed_out"
+		game.CompletionReason = "cash_out"
+		now := time.Now()
+		game.CompletedAt = &now
 
-	// Reveal all tiles
-	grid, _ := JSONToGrid(game.GridData)
-	for i := range grid {
-		grid[i].Revealed = true
-	}
-	gridJSON, _ := GridToJSON(grid)
-	game.GridData = gridJSON
-	game.UpdatedAt = time.Now()
+		// Reveal all tiles
+		grid, _ := JSONToGrid(game.GridData)
+		for i := range grid {
+			grid[i].Revealed = true
+		}
+		gridJSON, _ := GridToJSON(grid)
+		game.GridData = gridJSON
+		game.UpdatedAt = time.Now()
 
-	err = s.userDB.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Save(game).Error; err != nil {
 			s.log.Named("CashOut").Error("Failed to update game", zap.Error(err))
 			return errors.New("failed to update game")
 		}
 
-		if err := tx.Model(&model.User{}).
-			Where("id = ?", userId).
-			Update("remaining_coin", gorm.Expr("remaining_coin + ?", game.CurrentPayout)).
-			Error; err != nil {
+		if err := stakegame.Credit(tx, userId, game.CurrentPayout); err != nil {
 			s.log.Named("CashOut").Error("Failed to credit winnings", zap.Error(err))
-			return errors.New("failed to credit winnings")
+			return err
 		}
 
 		return nil
@@ -337,9 +417,160 @@ func (s *stakeMineServiceImpl) CashOut(userId string, gameId string) (*model.Min
 	}
 
 	s.log.Named("CashOut").Info("Player cashed out", zap.String("gameId", gameId), zap.String("userId", userId), zap.Float64("payout", game.CurrentPayout))
+	s.trackCashOut(game)
 	return s.gameToDto(game, false)
 }
 
+// RunIdleGameSweeper settles idle games every sweep interval until ctx is cancelled
+func (s *stakeMineServiceImpl) RunIdleGameSweeper(ctx context.Context) {
+	interval := time.Duration(s.cfg.GetGame().MinesSweepInterval) * time.Second
+	if interval <= 0 || s.cfg.GetGame().MinesIdleTimeout <= 0 {
+		s.log.Named("RunIdleGameSweeper").Info("Idle game sweeper disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.SweepIdleGames(); err != nil {
+				s.log.Named("RunIdleGameSweeper").Error("Failed to sweep idle games", zap.Error(err))
+			}
+		}
+	}
+}
+
+// SweepIdleGames settles active games without a move for longer than the idle timeout:
+// games with revealed diamonds are cashed out, untouched ones are refunded or forfeited per policy
+func (s *stakeMineServiceImpl) SweepIdleGames() (int, error) {
+	idleSince := time.Now().Add(-time.Duration(s.cfg.GetGame().MinesIdleTimeout) * time.Second)
+
+	gameIds, err := s.repo.FindIdleActiveGameIds(idleSince, idleSweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, gameId := range gameIds {
+		game, err := s.settleIdleGame(gameId, idleSince)
+		if err != nil {
+			s.log.Named("SweepIdleGames").Error("Failed to settle idle game", zap.Error(err), zap.String("gameId", gameId))
+			continue
+		}
+		if game == nil {
+			continue
+		}
+		settled++
+
+		title, message := idleGameNotification(game)
+		if err := s.notificationSvc.Notify(game.UserId, "mines_expired", title, message); err != nil {
+			s.log.Named("SweepIdleGames").Warn("Failed to notify player", zap.Error(err), zap.String("gameId", gameId))
+		}
+	}
+
+	if settled > 0 {
+		s.log.Named("SweepIdleGames").Info("Settled idle games", zap.Int("count", settled))
+	}
+	return settled, nil
+}
+
+// settleIdleGame settles a single idle game, returning nil if a move or another sweeper got to it first
+func (s *stakeMineServiceImpl) settleIdleGame(gameId string, idleSince time.Time) (*model.MineGame, error) {
+	var game *model.MineGame
+
+	err := s.userDB.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockGame(tx, gameId)
+		if err != nil {
+			return err
+		}
+		if locked.Status != "active" || !locked.UpdatedAt.Before(idleSince) {
+			return nil
+		}
+
+		switch {
+		case locked.RevealedCount > 0:
+			locked.Status = "cashed_out"
+			locked.CompletionReason = "idle_cashout"
+		case s.cfg.GetGame().MinesUntouchedPolicy == "forfeit":
+			locked.Status = "expired"
+			locked.CompletionReason = "idle_forfeit"
+			locked.CurrentPayout = 0
+		default:
+			locked.Status = "refunded"
+			locked.CompletionReason = "idle_refund"
+			locked.CurrentPayout = locked.BetAmount
+		}
+
+		now := time.Now()
+		locked.CompletedAt = &now
+		locked.UpdatedAt = now
+
+		// Reveal all tiles
+		grid, _ := JSONToGrid(locked.GridData)
+		for i := range grid {
+			grid[i].Revealed = true
+		}
+		gridJSON, _ := GridToJSON(grid)
+		locked.GridData = gridJSON
+
+		if err := tx.Save(locked).Error; err != nil {
+			return err
+		}
+
+		if err := stakegame.Credit(tx, locked.UserId, locked.CurrentPayout); err != nil {
+			return err
+		}
+
+		game = locked
+		return nil
+	})
+
+	return game, err
+}
+
+func idleGameNotification(game *model.MineGame) (string, string) {
+	switch game.CompletionReason {
+	case "idle_cashout":
+		return "Mines game cashed out", fmt.Sprintf("Your idle mines game was cashed out at %.2fx for %.2f coins.", game.Multiplier, game.CurrentPayout)
+	case "idle_forfeit":
+		return "Mines game expired", fmt.Sprintf("Your idle mines game expired without any revealed tiles and the %.2f coin bet was forfeited.", game.BetAmount)
+	default:
+		return "Mines game refunded", fmt.Sprintf("Your idle mines game expired without any revealed tiles and the %.2f coin bet was refunded.", game.BetAmount)
+	}
+}
+
+// lockGame loads the game with a row lock held until the transaction ends
+func lockGame(tx *gorm.DB, gameId string) (*model.MineGame, error) {
+	var game model.MineGame
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", gameId).
+		First(&game).Error; err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// findGameForUpdate locks the game for the rest of the transaction and checks its ownership
+func (s *stakeMineServiceImpl) findGameForUpdate(tx *gorm.DB, userId string, gameId string, caller string) (*model.MineGame, error) {
+	game, err := lockGame(tx, gameId)
+	if err != nil {
+		s.log.Named(caller).Error("Game not found", zap.Error(err))
+		return nil, errors.New("game not found")
+	}
+
+	// Verify ownership
+	if game.UserId != userId {
+		s.log.Named(caller).Warn("Unauthorized access attempt", zap.String("userId", userId), zap.String("gameId", gameId))
+		return nil, errors.New("unauthorized: this is not your game")
+	}
+
+	return game, nil
+}
+
 func (s *stakeMineServiceImpl) GetGame(userId string, gameId string) (*model.MineGameDto, error) {
 	game, err := s.repo.FindById(gameId)
 	if err != nil {
@@ -371,16 +602,20 @@ func (s *stakeMineServiceImpl) GetGameHistory(userId string, limit int, offset i
 
 	history := make([]model.MineGameHistoryDto, len(games))
 	for i, game := range games {
+		bombCount, _ := s.gameBoard(&game)
 		history[i] = model.MineGameHistoryDto{
-			GameId:        game.Id,
-			BetAmount:     game.BetAmount,
-			RiskLevel:     game.RiskLevel,
-			Status:        game.Status,
-			FinalPayout:   game.CurrentPayout,
-			Multiplier:    game.Multiplier,
-			RevealedCount: game.RevealedCount,
-			CreatedAt:     game.CreatedAt,
-			CompletedAt:   game.CompletedAt,
+			GameId:           game.Id,
+			BetAmount:        game.BetAmount,
+			RiskLevel:        game.RiskLevel,
+			GridSize:         game.GridSize,
+			BombCount:        bombCount,
+			Status:           game.Status,
+			CompletionReason: game.CompletionReason,
+			FinalPayout:      game.CurrentPayout,
+			Multiplier:       game.Multiplier,
+			RevealedCount:    game.RevealedCount,
+			CreatedAt:        game.CreatedAt,
+			CompletedAt:      game.CompletedAt,
 		}
 	}
 
@@ -391,6 +626,241 @@ func (s *stakeMineServiceImpl) GetStats(userId string) (*model.MineGameStatsDto,
 	return s.repo.GetStatsByUserId(userId)
 }
 
+// GetSeed returns the hash of the server seed the user's next game will use, committing a new one if needed
+func (s *stakeMineServiceImpl) GetSeed(userId string) (*model.MineSeedDto, error) {
+	seed, err := s.repo.FindPendingSeedByUserId(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		seed, err = newServerSeed(userId)
+		if err != nil {
+			s.log.Named("GetSeed").Error("Failed to generate server seed", zap.Error(err))
+			return nil, errors.New("failed to generate server seed")
+		}
+		if err := s.repo.CreateSeed(seed); err != nil {
+			s.log.Named("GetSeed").Error("Failed to save server seed", zap.Error(err))
+			return nil, errors.New("failed to generate server seed")
+		}
+	} else if err != nil {
+		s.log.Named("GetSeed").Error("Failed to load server seed", zap.Error(err))
+		return nil, errors.New("failed to load server seed")
+	}
+
+	return &model.MineSeedDto{ServerSeedHash: seed.ServerSeedHash}, nil
+}
+
+// VerifyGame recomputes a finished game's grid from its revealed seeds and compares it with the stored one
+func (s *stakeMineServiceImpl) VerifyGame(userId string, gameId string) (*model.MineGameVerifyDto, error) {
+	game, err := s.repo.FindById(gameId)
+	if err != nil {
+		return nil, errors.New("game not found")
+	}
+
+	if game.UserId != userId {
+		return nil, errors.New("unauthorized")
+	}
+
+	if game.Status == "active" {
+		return nil, errors.New("game is still active")
+	}
+
+	if game.ServerSeed == "" {
+		return nil, errors.New("game was created before provably fair seeds were introduced")
+	}
+
+	grid, err := JSONToGrid(game.GridData)
+	if err != nil {
+		s.log.Named("VerifyGame").Error("Failed to parse grid", zap.Error(err))
+		return nil, errors.New("failed to load game data")
+	}
+
+	bombCount, _ := s.gameBoard(game)
+	fairGrid, err := GenerateFairGrid(game.ServerSeed, game.ClientSeed, game.Nonce, len(grid), bombCount)
+	if err != nil {
+		s.log.Named("VerifyGame").Error("Failed to generate grid", zap.Error(err))
+		return nil, errors.New("failed to recompute game grid")
+	}
+
+	bombIndices := GetBombIndices(fairGrid)
+	storedIndices := GetBombIndices(grid)
+	gridMatches := len(bombIndices) == len(storedIndices)
+	for i := 0; gridMatches && i < len(bombIndices); i++ {
+		gridMatches = bombIndices[i] == storedIndices[i]
+	}
+
+	return &model.MineGameVerifyDto{
+		GameId:         game.Id,
+		RiskLevel:      game.RiskLevel,
+		GridSize:       game.GridSize,
+		BombCount:      bombCount,
+		ServerSeed:     game.ServerSeed,
+		ServerSeedHash: game.ServerSeedHash,
+		ClientSeed:     game.ClientSeed,
+		Nonce:          game.Nonce,
+		BombIndices:    bombIndices,
+		HashMatches:    utils.HashServerSeed(game.ServerSeed) == game.ServerSeedHash,
+		GridMatches:    gridMatches,
+	}, nil
+}
+
+// GetReplay returns the reveals of a game in order, with the full grid once the game is finished
+func (s *stakeMineServiceImpl) GetReplay(userId string, gameId string) (*model.MineGameReplayDto, error) {
+	game, err := s.repo.FindById(gameId)
+	if err != nil {
+		return nil, errors.New("game not found")
+	}
+
+	if game.UserId != userId {
+		return nil, errors.New("unauthorized")
+	}
+
+	history, err := s.repo.FindHistoryByGameId(gameId)
+	if err != nil {
+		s.log.Named("GetReplay").Error("Failed to get history", zap.Error(err))
+		return nil, errors.New("failed to load game history")
+	}
+
+	steps := make([]model.MineReplayStepDto, len(history))
+	for i, h := range history {
+		steps[i] = model.MineReplayStepDto{
+			Step:        i + 1,
+			TileIndex:   h.TileIndex,
+			TileType:    h.TileType,
+			Multiplier:  h.Multiplier,
+			PayoutAtHit: h.PayoutAtHit,
+			CreatedAt:   h.CreatedAt,
+		}
+	}
+
+	bombCount, _ := s.gameBoard(game)
+	replay := &model.MineGameReplayDto{
+		GameId:           game.Id,
+		BetAmount:        game.BetAmount,
+		GridSize:         game.GridSize,
+		BombCount:        bombCount,
+		Status:           game.Status,
+		CompletionReason: game.CompletionReason,
+		FinalPayout:      game.CurrentPayout,
+		Multiplier:       game.Multiplier,
+		Steps:            steps,
+	}
+
+	if game.Status != "active" {
+		grid, err := JSONToGrid(game.GridData)
+		if err != nil {
+			s.log.Named("GetReplay").Error("Failed to parse grid", zap.Error(err))
+			return nil, errors.New("failed to load game data")
+		}
+
+		replay.FinalGrid = make([]model.MineTileDto, len(grid))
+		for i, tile := range grid {
+			replay.FinalGrid[i] = model.MineTileDto{
+				Index:    tile.Index,
+				Type:     tile.Type,
+				Revealed: tile.Revealed,
+			}
+		}
+	}
+
+	return replay, nil
+}
+
+// GetLeaderboard returns a mines leaderboard, served from cache until the configured TTL expires
+func (s *stakeMineServiceImpl) GetLeaderboard(board string, period string, colorId string, limit int) (*model.MineLeaderboardDto, error) {
+	if !ValidateLeaderboard(board) {
+		return nil, errors.New("invalid leaderboard type")
+	}
+	if period == "" {
+		period = "all"
+	}
+
+	now := time.Now()
+	since, err := GetPeriodStart(period, now)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("mines/leaderboard/%v/%v/%v/%v", board, period, colorId, limit)
+
+	var cached model.MineLeaderboardDto
+	if err := s.repo.GetLeaderboardCache(key, &cached); err == nil {
+		return &cached, nil
+	} else if err != redis.Nil {
+		s.log.Named("GetLeaderboard").Warn("Failed to read leaderboard cache", zap.Error(err))
+	}
+
+	entries, err := s.repo.GetLeaderboard(board, since, colorId, limit)
+	if err != nil {
+		s.log.Named("GetLeaderboard").Error("Failed to get leaderboard", zap.Error(err))
+		return nil, errors.New("failed to get leaderboard")
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	leaderboard := &model.MineLeaderboardDto{
+		Type:        board,
+		Period:      period,
+		ColorId:     colorId,
+		Entries:     entries,
+		GeneratedAt: now,
+	}
+
+	if err := s.repo.SetLeaderboardCache(key, leaderboard, s.cfg.GetGame().MinesLeaderboardTTL); err != nil {
+		s.log.Named("GetLeaderboard").Warn("Failed to cache leaderboard", zap.Error(err))
+	}
+
+	return leaderboard, nil
+}
+
+func newServerSeed(userId string) (*model.MineServerSeed, error) {
+	serverSeed, err := utils.GenerateServerSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MineServerSeed{
+		Id:             uuid.New().String(),
+		UserId:         userId,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: utils.HashServerSeed(serverSeed),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
+// trackCashOut reports a game the player finished with a payout to their quests
+func (s *stakeMineServiceImpl) trackCashOut(game *model.MineGame) {
+	s.questTracker.Track(game.UserId, &quest.Event{
+		Type:       quest.EventMinesCashedOut,
+		Multiplier: game.Multiplier,
+	})
+}
+
+// autoCashoutReached reports whether the game hit the player's auto cash-out target
+func autoCashoutReached(game *model.MineGame) bool {
+	if game.AutoCashoutDiamonds > 0 && game.RevealedCount >= game.AutoCashoutDiamonds {
+		return true
+	}
+	return game.AutoCashoutMultiplier > 0 && game.Multiplier >= game.AutoCashoutMultiplier
+}
+
+// gameMultiplier prices the game's current diamonds, games created before custom boards keep the table
+// they started with so their payout does not change mid-game
+func gameMultiplier(game *model.MineGame, tileCount int, bombCount int, houseEdge float64) float64 {
+	if game.BombCount == 0 {
+		return LegacyMultiplier(game.RevealedCount, game.RiskLevel)
+	}
+	return CalculateMultiplier(game.RevealedCount, tileCount, bombCount, houseEdge)
+}
+
+// gameBoard returns the bomb count and house edge of a game, falling back to the risk level preset
+// and the configured house edge for games created before custom boards
+func (s *stakeMineServiceImpl) gameBoard(game *model.MineGame) (int, float64) {
+	if game.BombCount == 0 {
+		return GetBombCount(game.RiskLevel), s.cfg.GetGame().MinesHouseEdge
+	}
+	return game.BombCount, game.HouseEdge
+}
+
 // Helper function to convert game entity to DTO
 func (s *stakeMineServiceImpl) gameToDto(game *model.MineGame, hideUnrevealed bool) (*model.MineGameDto, error) {
 	grid, err := JSONToGrid(game.GridData)
@@ -408,17 +878,35 @@ func (s *stakeMineServiceImpl) gameToDto(game *model.MineGame, hideUnrevealed bo
 		}
 	}
 
+	// The server seed stays secret until the game is over
+	var serverSeed string
+	if game.Status != "active" {
+		serverSeed = game.ServerSeed
+	}
+
+	bombCount, houseEdge := s.gameBoard(game)
+
 	return &model.MineGameDto{
-		Id:            game.Id,
-		UserId:        game.UserId,
-		BetAmount:     game.BetAmount,
-		RiskLevel:     game.RiskLevel,
-		Grid:          tiles,
-		RevealedCount: game.RevealedCount,
-		CurrentPayout: game.CurrentPayout,
-		Multiplier:    game.Multiplier,
-		Status:        game.Status,
-		CreatedAt:     game.CreatedAt,
-		CompletedAt:   game.CompletedAt,
+		Id:                    game.Id,
+		UserId:                game.UserId,
+		BetAmount:             game.BetAmount,
+		RiskLevel:             game.RiskLevel,
+		GridSize:              game.GridSize,
+		BombCount:             bombCount,
+		HouseEdge:             houseEdge,
+		Grid:                  tiles,
+		RevealedCount:         game.RevealedCount,
+		CurrentPayout:         game.CurrentPayout,
+		Multiplier:            game.Multiplier,
+		Status:                game.Status,
+		CompletionReason:      game.CompletionReason,
+		ServerSeedHash:        game.ServerSeedHash,
+		ServerSeed:            serverSeed,
+		ClientSeed:            game.ClientSeed,
+		Nonce:                 game.Nonce,
+		AutoCashoutMultiplier: game.AutoCashoutMultiplier,
+		AutoCashoutDiamonds:   game.AutoCashoutDiamonds,
+		CreatedAt:             game.CreatedAt,
+		CompletedAt:           game.CompletedAt,
 	}, nil
 }
@@ -0,0 +1,269 @@
+package stakemine
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/quest"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/cache"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// concurrentCalls is how many requests each test fires at one game at the same time
+const concurrentCalls = 16
+
+type noopQuestTracker struct{}
+
+func (noopQuestTracker) Track(userId string, event *quest.Event) {}
+
+// openTestDB connects to the Postgres database in TEST_DATABASE_DSN, the row locks under test
+// need a real database so the tests are skipped without one
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect database: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&model.Role{},
+		&model.Color{},
+		&model.IntaniaGroup{},
+		&model.User{},
+		&model.Season{},
+		&model.MineGame{},
+		&model.MineGameHistory{},
+	); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := db.Where(model.Role{ID: model.RoleUser}).FirstOrCreate(&model.Role{ID: model.RoleUser}).Error; err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	return db
+}
+
+func newTestService(db *gorm.DB) StakeMineService {
+	return NewStakeMineService(NewStakeMineRepository(db, cache.RedisClient{}), db, nil, noopQuestTracker{}, nil, zap.NewNop())
+}
+
+// createTestGame stores a user holding balance and an active 4x4 game whose bombs sit on bombTiles
+func createTestGame(t *testing.T, db *gorm.DB, balance float64, revealed []int, bombTiles ...int) (*model.User, *model.MineGame) {
+	t.Helper()
+
+	user := &model.User{
+		Id:            uuid.NewString(),
+		Email:         uuid.NewString() + "@test.local",
+		Name:          "mines test",
+		RoleId:        model.RoleUser,
+		RemainingCoin: balance,
+	}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	grid := make([]Tile, DefaultGridSize*DefaultGridSize)
+	for i := range grid {
+		grid[i] = Tile{Index: i, Type: "diamond"}
+	}
+	for _, i := range bombTiles {
+		grid[i].Type = "bomb"
+	}
+	for _, i := range revealed {
+		grid[i].Revealed = true
+	}
+	gridJSON, err := GridToJSON(grid)
+	if err != nil {
+		t.Fatalf("grid to json: %v", err)
+	}
+
+	bombCount := len(bombTiles)
+	game := &model.MineGame{
+		Id:            uuid.NewString(),
+		UserId:        user.Id,
+		BetAmount:     100,
+		RiskLevel:     "custom",
+		GridSize:      DefaultGridSize,
+		BombCount:     bombCount,
+		Status:        "active",
+		RevealedCount: len(revealed),
+		Multiplier:    CalculateMultiplier(len(revealed), len(grid), bombCount, 0),
+		GridData:      gridJSON,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	game.CurrentPayout, _ = CalculatePayoutSafe(game.BetAmount, game.Multiplier)
+	if err := db.Create(game).Error; err != nil {
+		t.Fatalf("create game: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Where("game_id = ?", game.Id).Delete(&model.MineGameHistory{})
+		db.Where("id = ?", game.Id).Delete(&model.MineGame{})
+		db.Where("id = ?", user.Id).Delete(&model.User{})
+	})
+	return user, game
+}
+
+func reload(t *testing.T, db *gorm.DB, user *model.User, game *model.MineGame) (*model.User, *model.MineGame) {
+	t.Helper()
+
+	var u model.User
+	if err := db.First(&u, "id = ?", user.Id).Error; err != nil {
+		t.Fatalf("reload user: %v", err)
+	}
+	var g model.MineGame
+	if err := db.First(&g, "id = ?", game.Id).Error; err != nil {
+		t.Fatalf("reload game: %v", err)
+	}
+	return &u, &g
+}
+
+// runConcurrently starts every call at once and returns how many succeeded
+func runConcurrently(calls []func() error) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	start := make(chan struct{})
+	succeeded := 0
+
+	for _, call := range calls {
+		wg.Add(1)
+		go func(call func() error) {
+			defer wg.Done()
+			<-start
+			if err := call(); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}(call)
+	}
+
+	close(start)
+	wg.Wait()
+	return succeeded
+}
+
+func TestCashOutConcurrentCreditsOnce(t *testing.T) {
+	db := openTestDB(t)
+	svc := newTestService(db)
+	user, game := createTestGame(t, db, 1000, []int{0, 1}, 15)
+
+	calls := make([]func() error, concurrentCalls)
+	for i := range calls {
+		calls[i] = func() error {
+			_, err := svc.CashOut(user.Id, game.Id)
+			return err
+		}
+	}
+
+	if succeeded := runConcurrently(calls); succeeded != 1 {
+		t.Fatalf("expected exactly one cash-out to succeed, got %d", succeeded)
+	}
+
+	gotUser, gotGame := reload(t, db, user, game)
+	if gotGame.Status != "cashed_out" {
+		t.Fatalf("expected status cashed_out, got %s", gotGame.Status)
+	}
+	if want := user.RemainingCoin + game.CurrentPayout; gotUser.RemainingCoin != want {
+		t.Fatalf("expected balance %.2f after a single credit, got %.2f", want, gotUser.RemainingCoin)
+	}
+}
+
+func TestRevealBombAndCashOutConcurrentApplyOneOutcome(t *testing.T) {
+	db := openTestDB(t)
+	svc := newTestService(db)
+	user, game := createTestGame(t, db, 1000, []int{0}, 15)
+
+	calls := make([]func() error, 0, concurrentCalls*2)
+	for i := 0; i < concurrentCalls; i++ {
+		calls = append(calls,
+			func() error {
+				_, _, err := svc.RevealTile(user.Id, game.Id, &model.RevealMineTileRequest{Index: 15})
+				return err
+			},
+			func() error {
+				_, err := svc.CashOut(user.Id, game.Id)
+				return err
+			},
+		)
+	}
+
+	if succeeded := runConcurrently(calls); succeeded != 1 {
+		t.Fatalf("expected exactly one reveal or cash-out to end the game, got %d", succeeded)
+	}
+
+	gotUser, gotGame := reload(t, db, user, game)
+	switch gotGame.Status {
+	case "lost":
+		if gotUser.RemainingCoin != user.RemainingCoin {
+			t.Fatalf("lost game must not pay, balance went from %.2f to %.2f", user.RemainingCoin, gotUser.RemainingCoin)
+		}
+	case "cashed_out":
+		if want := user.RemainingCoin + game.CurrentPayout; gotUser.RemainingCoin != want {
+			t.Fatalf("expected balance %.2f after a single credit, got %.2f", want, gotUser.RemainingCoin)
+		}
+	default:
+		t.Fatalf("expected the game to be lost or cashed out, got %s", gotGame.Status)
+	}
+}
+
+func TestRevealConcurrentDoesNotLoseTiles(t *testing.T) {
+	db := openTestDB(t)
+	svc := newTestService(db)
+	user, game := createTestGame(t, db, 1000, nil, 15)
+
+	// Every call reveals a different diamond, so all of them must be applied
+	calls := make([]func() error, 10)
+	for i := range calls {
+		index := i
+		calls[i] = func() error {
+			_, _, err := svc.RevealTile(user.Id, game.Id, &model.RevealMineTileRequest{Index: index})
+			return err
+		}
+	}
+
+	succeeded := runConcurrently(calls)
+	if succeeded != len(calls) {
+		t.Fatalf("expected all %d reveals to succeed, got %d", len(calls), succeeded)
+	}
+
+	gotUser, gotGame := reload(t, db, user, game)
+	if gotGame.Status != "active" || gotGame.RevealedCount != len(calls) {
+		t.Fatalf("expected an active game with %d revealed tiles, got %s with %d", len(calls), gotGame.Status, gotGame.RevealedCount)
+	}
+
+	grid, err := JSONToGrid(gotGame.GridData)
+	if err != nil {
+		t.Fatalf("parse grid: %v", err)
+	}
+	revealed := 0
+	for _, tile := range grid {
+		if tile.Revealed {
+			revealed++
+		}
+	}
+	if revealed != len(calls) {
+		t.Fatalf("expected %d revealed tiles in the grid, got %d", len(calls), revealed)
+	}
+
+	var history int64
+	db.Model(&model.MineGameHistory{}).Where("game_id = ?", game.Id).Count(&history)
+	if int(history) != len(calls) {
+		t.Fatalf("expected %d history rows, got %d", len(calls), history)
+	}
+	if gotUser.RemainingCoin != user.RemainingCoin {
+		t.Fatalf("reveals must not move coins, balance went from %.2f to %.2f", user.RemainingCoin, gotUser.RemainingCoin)
+	}
+}
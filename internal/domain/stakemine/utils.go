@@ -5,7 +5,12 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/utils"
 )
 
 // Tile represents a single tile in the grid
@@ -15,8 +20,54 @@ type Tile struct {
 	Revealed bool   `json:"revealed"`
 }
 
-// Pre-calculated multiplier lookup tables based on your probability data
-var multiplierTable = map[string]map[int]float64{
+const (
+	DefaultGridSize = 4 // side length of the classic 4x4 board
+	MinGridSize     = 2
+	MaxGridSize     = 8
+
+	// MaxMultiplier caps the payout multiplier so large boards with many bombs cannot overflow balances
+	MaxMultiplier = 10000.0
+)
+
+// Leaderboards available for mines
+const (
+	LeaderboardBiggestCashout    = "biggest_cashout"
+	LeaderboardHighestMultiplier = "highest_multiplier"
+	LeaderboardNetProfit         = "net_profit"
+	LeaderboardLongestStreak     = "longest_streak"
+)
+
+// ValidateLeaderboard checks if the leaderboard type is valid
+func ValidateLeaderboard(board string) bool {
+	switch board {
+	case LeaderboardBiggestCashout, LeaderboardHighestMultiplier, LeaderboardNetProfit, LeaderboardLongestStreak:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetPeriodStart returns the start of a leaderboard period, or nil for all time
+func GetPeriodStart(period string, now time.Time) (*time.Time, error) {
+	var start time.Time
+	switch period {
+	case "", "all":
+		return nil, nil
+	case "day":
+		start = now.AddDate(0, 0, -1)
+	case "week":
+		start = now.AddDate(0, 0, -7)
+	case "month":
+		start = now.AddDate(0, -1, 0)
+	default:
+		return nil, errors.New("invalid period. must be 'day', 'week', 'month' or 'all'")
+	}
+	return &start, nil
+}
+
+// legacyMultiplierTable pays games created before custom boards, which were priced from this table
+// when they started and must keep paying from it until they finish
+var legacyMultiplierTable = map[string]map[int]float64{
 	"low": { // Easy (σ=0.9, 2 gn) - 2 bombs, 14 diamonds
 		0:  1.0,
 		1:  1.03,
@@ -64,7 +115,17 @@ var multiplierTable = map[string]map[int]float64{
 	},
 }
 
-// GetBombCount returns number of bombs based on risk level
+// LegacyMultiplier returns the table multiplier of a game created before custom boards
+func LegacyMultiplier(diamondsFound int, risk string) float64 {
+	if riskTable, exists := legacyMultiplierTable[risk]; exists {
+		if multiplier, exists := riskTable[diamondsFound]; exists {
+			return multiplier
+		}
+	}
+	return 1.0
+}
+
+// GetBombCount returns number of bombs based on risk level on the classic 4x4 board
 func GetBombCount(risk string) int {
 	switch risk {
 	case "low":
@@ -78,22 +139,54 @@ func GetBombCount(risk string) int {
 	}
 }
 
-// CalculateMultiplier returns the pre-calculated multiplier from lookup table
-func CalculateMultiplier(diamondsFound int, risk string) float64 {
-	// Get multiplier from lookup table
-	if riskTable, exists := multiplierTable[risk]; exists {
-		if multiplier, exists := riskTable[diamondsFound]; exists {
-			return multiplier
+// GetBombCountForGrid scales a risk level preset to a board with the given number of tiles
+func GetBombCountForGrid(risk string, tileCount int) int {
+	tiles := DefaultGridSize * DefaultGridSize
+	bombs := int(math.Round(float64(GetBombCount(risk)*tileCount) / float64(tiles)))
+	if bombs < 1 {
+		bombs = 1
+	}
+	if bombs > tileCount-1 {
+		bombs = tileCount - 1
+	}
+	return bombs
+}
+
+// CalculateMultiplier returns the fair multiplier for finding diamondsFound diamonds, C(tiles, k) / C(tiles-bombs, k),
+// reduced by the house edge and rounded down to two decimals
+func CalculateMultiplier(diamondsFound int, tileCount int, bombCount int, houseEdge float64) float64 {
+	if diamondsFound <= 0 {
+		return 1.0
+	}
+	if diamondsFound > tileCount-bombCount {
+		diamondsFound = tileCount - bombCount
+	}
+
+	multiplier := 1 - houseEdge
+	for i := 0; i < diamondsFound; i++ {
+		multiplier *= float64(tileCount-i) / float64(tileCount-bombCount-i)
+		if multiplier >= MaxMultiplier {
+			return MaxMultiplier
 		}
 	}
 
-	// Fallback to 1.0 if not found (should never happen)
-	return 1.0
+	return math.Floor(multiplier*100) / 100
+}
+
+// GetMaxDiamonds returns maximum diamonds for a board
+func GetMaxDiamonds(tileCount int, bombCount int) int {
+	return tileCount - bombCount
 }
 
-// GetMaxDiamonds returns maximum diamonds for a risk level
-func GetMaxDiamonds(risk string) int {
-	return 16 - GetBombCount(risk)
+// ValidateBoard checks that the grid size and bomb count make a playable board
+func ValidateBoard(gridSize int, bombCount int) error {
+	if gridSize < MinGridSize || gridSize > MaxGridSize {
+		return fmt.Errorf("grid size must be between %d and %d", MinGridSize, MaxGridSize)
+	}
+	if bombCount < 1 || bombCount > gridSize*gridSize-1 {
+		return fmt.Errorf("bomb count must be between 1 and %d", gridSize*gridSize-1)
+	}
+	return nil
 }
 
 // SecureRandom generates a cryptographically secure random number between 0 and max-1
@@ -105,12 +198,29 @@ func SecureRandom(max int) (int, error) {
 	return int(n.Int64()), nil
 }
 
-func GenerateGrid(risk string) ([]Tile, error) {
-	bombCount := GetBombCount(risk)
-	grid := make([]Tile, 16)
+// GenerateGrid shuffles bombs into a fresh grid using crypto/rand
+func GenerateGrid(tileCount int, bombCount int) ([]Tile, error) {
+	return shuffleGrid(tileCount, bombCount, SecureRandom)
+}
+
+// GenerateFairGrid shuffles bombs into a fresh grid using numbers derived from the seeds,
+// so anyone holding the revealed server seed can recompute the same grid
+func GenerateFairGrid(serverSeed, clientSeed string, nonce int, tileCount int, bombCount int) ([]Tile, error) {
+	floats := utils.FairFloats(serverSeed, clientSeed, nonce, tileCount-1)
+	next := 0
+
+	return shuffleGrid(tileCount, bombCount, func(max int) (int, error) {
+		n := int(floats[next] * float64(max))
+		next++
+		return n, nil
+	})
+}
+
+func shuffleGrid(tileCount int, bombCount int, random func(max int) (int, error)) ([]Tile, error) {
+	grid := make([]Tile, tileCount)
 
 	// Initialize all tiles as diamonds
-	for i := 0; i < 16; i++ {
+	for i := 0; i < tileCount; i++ {
 		grid[i] = Tile{
 			Index:    i,
 			Type:     "diamond",
@@ -119,13 +229,13 @@ func GenerateGrid(risk string) ([]Tile, error) {
 	}
 
 	//Fisher-Yates
-	indices := make([]int, 16)
+	indices := make([]int, tileCount)
 	for i := range indices {
 		indices[i] = i
 	}
 
-	for i := 15; i > 0; i-- {
-		j, err := SecureRandom(i + 1)
+	for i := tileCount - 1; i > 0; i-- {
+		j, err := random(i + 1)
 		if err != nil {
 			return nil, err
 		}
@@ -139,6 +249,17 @@ func GenerateGrid(risk string) ([]Tile, error) {
 	return grid, nil
 }
 
+// GetBombIndices returns the indices of all bombs in the grid
+func GetBombIndices(grid []Tile) []int {
+	bombs := []int{}
+	for _, tile := range grid {
+		if tile.Type == "bomb" {
+			bombs = append(bombs, tile.Index)
+		}
+	}
+	return bombs
+}
+
 // GridToJSON converts grid to JSON string for database storage
 func GridToJSON(grid []Tile) (string, error) {
 	data, err := json.Marshal(grid)
@@ -178,8 +299,8 @@ func ValidateRiskLevel(risk string) bool {
 }
 
 // ValidateTileIndex checks if tile index is valid
-func ValidateTileIndex(index int) bool {
-	return index >= 0 && index < 16
+func ValidateTileIndex(index int, tileCount int) bool {
+	return index >= 0 && index < tileCount
 }
 
 // ValidateBetAmount
@@ -0,0 +1,273 @@
+package teampool
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type teamPoolRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTeamPoolRepository(db *gorm.DB) TeamPoolRepository {
+	return &teamPoolRepositoryImpl{db: db}
+}
+
+func (r *teamPoolRepositoryImpl) FindMatch(matchId string) (*model.Match, error) {
+	var match model.Match
+	if err := r.db.Where("id = ?", matchId).First(&match).Error; err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *teamPoolRepositoryImpl) FindColors() ([]model.Color, error) {
+	var colors []model.Color
+	if err := r.db.Order("id ASC").Find(&colors).Error; err != nil {
+		return nil, err
+	}
+	return colors, nil
+}
+
+func (r *teamPoolRepositoryImpl) FindUserColorId(userId string) (string, error) {
+	var colorId string
+	err := r.db.Model(&model.User{}).
+		Joins("JOIN intania_groups ON intania_groups.id = users.group_id").
+		Where("users.id = ?", userId).
+		Pluck("intania_groups.color_id", &colorId).Error
+	if err != nil {
+		return "", err
+	}
+	return colorId, nil
+}
+
+func (r *teamPoolRepositoryImpl) FindPoolsByTarget(target string) ([]model.TeamPool, error) {
+	var pools []model.TeamPool
+	err := r.db.Preload("Color").
+		Where("target = ?", target).
+		Order("total_amount DESC").
+		Find(&pools).Error
+	if err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (r *teamPoolRepositoryImpl) FindContributionsByUserId(userId string, target string) ([]model.TeamPoolContribution, error) {
+	query := r.db.Preload("Pool.Color").
+		Joins("JOIN team_pools ON team_pools.id = team_pool_contributions.pool_id").
+		Where("team_pool_contributions.user_id = ?", userId)
+	if target != "" {
+		query = query.Where("team_pools.target = ?", target)
+	}
+
+	var contributions []model.TeamPoolContribution
+	if err := query.Order("team_pool_contributions.updated_at DESC").Find(&contributions).Error; err != nil {
+		return nil, err
+	}
+	return contributions, nil
+}
+
+// Contribute moves coins from the user into their color's pool for target,
+// pools are always locked before users so settlement cannot deadlock with contributions
+func (r *teamPoolRepositoryImpl) Contribute(target string, colorId string, userId string, amount float64, now time.Time) (*model.TeamPoolContribution, error) {
+	var contribution model.TeamPoolContribution
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		pool := model.TeamPool{
+			Id:        uuid.NewString(),
+			Target:    target,
+			ColorId:   colorId,
+			Status:    PoolOpen,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&pool).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("target = ? AND color_id = ?", target, colorId).
+			First(&pool).Error; err != nil {
+			return err
+		}
+		if pool.Status != PoolOpen {
+			return errors.New("team pool is already settled")
+		}
+
+		// A pool opened after its target was settled must not take coins either
+		var settled int64
+		if err := tx.Model(&model.TeamPool{}).
+			Where("target = ? AND status <> ?", target, PoolOpen).
+			Count(&settled).Error; err != nil {
+			return err
+		}
+		if settled > 0 {
+			return errors.New("team pool is already settled")
+		}
+
+		user, err := stakegame.LockUser(tx, userId)
+		if err != nil {
+			return err
+		}
+		if err := stakegame.Debit(tx, user, amount); err != nil {
+			return err
+		}
+
+		err = tx.Where("pool_id = ? AND user_id = ?", pool.Id, userId).First(&contribution).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			contribution = model.TeamPoolContribution{
+				Id:        uuid.NewString(),
+				PoolId:    pool.Id,
+				UserId:    userId,
+				CreatedAt: now,
+			}
+			pool.ContributorCount++
+		} else if err != nil {
+			return err
+		}
+
+		contribution.Amount += amount
+		contribution.UpdatedAt = now
+		if err := tx.Save(&contribution).Error; err != nil {
+			return err
+		}
+
+		pool.TotalAmount += amount
+		pool.UpdatedAt = now
+		if err := tx.Save(&pool).Error; err != nil {
+			return err
+		}
+
+		contribution.Pool = pool
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &contribution, nil
+}
+
+// Settle pays out every open pool of target, the pool of winnerId shares the whole pot
+// and everyone is refunded when there is no winner, nobody backed it or nobody backed anyone else.
+// Every color in colorIds gets a pool first, so settling closes the target for colors nobody backed too.
+func (r *teamPoolRepositoryImpl) Settle(target string, colorIds []string, winnerId string, houseEdge float64, now time.Time) ([]model.TeamPool, error) {
+	var pools []model.TeamPool
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, colorId := range colorIds {
+			pool := model.TeamPool{
+				Id:        uuid.NewString(),
+				Target:    target,
+				ColorId:   colorId,
+				Status:    PoolOpen,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&pool).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("target = ? AND status = ?", target, PoolOpen).
+			Order("id ASC").
+			Find(&pools).Error; err != nil {
+			return err
+		}
+
+		var winner *model.TeamPool
+		for i := range pools {
+			if pools[i].ColorId == winnerId && pools[i].TotalAmount > 0 {
+				winner = &pools[i]
+			}
+		}
+		pot := SettledPot(pools, winner, houseEdge)
+		if pot == 0 {
+			winner = nil
+		}
+
+		for i := range pools {
+			pool := &pools[i]
+
+			var contributions []model.TeamPoolContribution
+			if err := tx.Where("pool_id = ?", pool.Id).Find(&contributions).Error; err != nil {
+				return err
+			}
+
+			switch {
+			case winner == nil:
+				pool.Status = PoolRefunded
+			case pool == winner:
+				pool.Status = PoolWon
+			default:
+				pool.Status = PoolLost
+			}
+
+			for j := range contributions {
+				contribution := &contributions[j]
+				switch pool.Status {
+				case PoolRefunded:
+					contribution.Payout = contribution.Amount
+				case PoolWon:
+					contribution.Payout = ContributorShare(pot, pool.TotalAmount, contribution.Amount)
+				default:
+					continue
+				}
+
+				if err := stakegame.Credit(tx, contribution.UserId, contribution.Payout); err != nil {
+					return err
+				}
+				contribution.UpdatedAt = now
+				if err := tx.Save(contribution).Error; err != nil {
+					return err
+				}
+				pool.Payout += contribution.Payout
+			}
+
+			pool.SettledAt = &now
+			pool.UpdatedAt = now
+			if err := tx.Save(pool).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pools, nil
+}
+
+func (r *teamPoolRepositoryImpl) GetColorLeaderboard() ([]model.TeamPoolLeaderboardDto, error) {
+	var leaderboard []model.TeamPoolLeaderboardDto
+
+	err := r.db.Model(&model.Color{}).
+		Select(`colors.id AS color_id,
+			colors.title AS title,
+			COALESCE(SUM(team_pools.total_amount), 0) AS total_contributed,
+			COALESCE(SUM(team_pools.payout), 0) AS total_payout,
+			COALESCE(SUM(team_pools.payout - team_pools.total_amount) FILTER (WHERE team_pools.status <> ?), 0) AS net_profit,
+			COUNT(team_pools.id) FILTER (WHERE team_pools.status = ?) AS pools_won,
+			COUNT(team_pools.id) FILTER (WHERE team_pools.status <> ? AND team_pools.total_amount > 0) AS pools_settled,
+			(SELECT COUNT(DISTINCT team_pool_contributions.user_id)
+				FROM team_pool_contributions
+				JOIN team_pools AS color_pools ON color_pools.id = team_pool_contributions.pool_id
+				WHERE color_pools.color_id = colors.id) AS contributors`, PoolOpen, PoolWon, PoolOpen).
+		Joins("LEFT JOIN team_pools ON team_pools.color_id = colors.id").
+		Group("colors.id, colors.title").
+		Order("net_profit DESC, total_contributed DESC").
+		Scan(&leaderboard).Error
+	if err != nil {
+		return nil, err
+	}
+	return leaderboard, nil
+}
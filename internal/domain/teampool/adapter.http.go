@@ -0,0 +1,154 @@
+package teampool
+
+import (
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TeamPoolHttpHandler struct {
+	service TeamPoolService
+}
+
+func NewTeamPoolHttpHandler(service TeamPoolService) *TeamPoolHttpHandler {
+	return &TeamPoolHttpHandler{service: service}
+}
+
+func (h *TeamPoolHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/team-pools", mid.AuthMiddleware)
+
+	router.Get("", h.GetPools)
+	router.Post("/contribute", h.Contribute)
+	router.Get("/me", h.GetMyContributions)
+	router.Get("/leaderboard", h.GetLeaderboard)
+
+	adminRouter := router.Group("/admin")
+	adminRouter.Post("/championship/settle", mid.RequirePermission(model.PermMatchSettle), h.SettleChampionship)
+}
+
+// @Summary Get team pools
+// @Description Get every color's pool for a match, or for the overall championship when match_id is omitted, with the user's own contribution
+// @Tags TeamPool
+// @Produce json
+// @Param match_id query string false "Match ID"
+// @Success 200 {array} model.TeamPoolDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /team-pools [get]
+// @Security BearerAuth
+func (h *TeamPoolHttpHandler) GetPools(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	pools, err := h.service.GetPools(profile.Id, c.Query("match_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(pools)
+}
+
+// @Summary Contribute to a team pool
+// @Description Put coins into the pool backing your own color for a match that has not started, or for the championship. Winning pools share every pool of the match in proportion to contributions.
+// @Tags TeamPool
+// @Accept json
+// @Produce json
+// @Param request body model.ContributeTeamPoolRequest true "Contribution"
+// @Success 201 {object} model.TeamPoolContributionDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /team-pools/contribute [post]
+// @Security BearerAuth
+func (h *TeamPoolHttpHandler) Contribute(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.ContributeTeamPoolRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	contribution, err := h.service.Contribute(profile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(contribution)
+}
+
+// @Summary Get my team pool contributions
+// @Description Get every pool the user contributed to with its status and the user's payout
+// @Tags TeamPool
+// @Produce json
+// @Success 200 {array} model.TeamPoolContributionDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /team-pools/me [get]
+// @Security BearerAuth
+func (h *TeamPoolHttpHandler) GetMyContributions(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	contributions, err := h.service.GetMyContributions(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get contributions",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(contributions)
+}
+
+// @Summary Get team pool leaderboard
+// @Description Rank colors by the net profit of their settled pools
+// @Tags TeamPool
+// @Produce json
+// @Success 200 {array} model.TeamPoolLeaderboardDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /team-pools/leaderboard [get]
+// @Security BearerAuth
+func (h *TeamPoolHttpHandler) GetLeaderboard(c *fiber.Ctx) error {
+	leaderboard, err := h.service.GetLeaderboard()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get leaderboard",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(leaderboard)
+}
+
+// @Summary Settle championship pools
+// @Description Pay the championship pools out to the winning color, or refund them all when winner_id is empty (admin only)
+// @Tags TeamPool
+// @Accept json
+// @Produce json
+// @Param request body model.SettleChampionshipRequest true "Winning color"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /team-pools/admin/championship/settle [post]
+// @Security BearerAuth
+func (h *TeamPoolHttpHandler) SettleChampionship(c *fiber.Ctx) error {
+	var req model.SettleChampionshipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	if err := h.service.SettleChampionship(req.WinnerId); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Championship pools settled",
+	})
+}
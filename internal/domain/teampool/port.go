@@ -0,0 +1,33 @@
+package teampool
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+// MatchSettler is how the match domain settles team pools once a match has a result
+type MatchSettler interface {
+	SettleMatch(matchId string) error
+}
+
+type TeamPoolService interface {
+	MatchSettler
+
+	Contribute(userId string, req *model.ContributeTeamPoolRequest) (*model.TeamPoolContributionDto, error)
+	GetPools(userId string, matchId string) ([]model.TeamPoolDto, error)
+	GetMyContributions(userId string) ([]model.TeamPoolContributionDto, error)
+	GetLeaderboard() ([]model.TeamPoolLeaderboardDto, error)
+	SettleChampionship(winnerId string) error
+}
+
+type TeamPoolRepository interface {
+	FindMatch(matchId string) (*model.Match, error)
+	FindColors() ([]model.Color, error)
+	FindUserColorId(userId string) (string, error)
+	FindPoolsByTarget(target string) ([]model.TeamPool, error)
+	FindContributionsByUserId(userId string, target string) ([]model.TeamPoolContribution, error)
+	Contribute(target string, colorId string, userId string, amount float64, now time.Time) (*model.TeamPoolContribution, error)
+	Settle(target string, colorIds []string, winnerId string, houseEdge float64, now time.Time) ([]model.TeamPool, error)
+	GetColorLeaderboard() ([]model.TeamPoolLeaderboardDto, error)
+}
@@ -0,0 +1,239 @@
+package teampool
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"go.uber.org/zap"
+)
+
+type teamPoolServiceImpl struct {
+	repo TeamPoolRepository
+	cfg  config.Config
+	log  *zap.Logger
+}
+
+func NewTeamPoolService(repo TeamPoolRepository, cfg config.Config, log *zap.Logger) TeamPoolService {
+	return &teamPoolServiceImpl{
+		repo: repo,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// Contribute adds coins to the pool backing the user's own color
+func (s *teamPoolServiceImpl) Contribute(userId string, req *model.ContributeTeamPoolRequest) (*model.TeamPoolContributionDto, error) {
+	if err := stakegame.ValidateBetAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	colorId, err := s.repo.FindUserColorId(userId)
+	if err != nil {
+		s.log.Named("Contribute").Error("FindUserColorId", zap.Error(err))
+		return nil, err
+	}
+	if colorId == "" {
+		return nil, errors.New("you must belong to a color to join a team pool")
+	}
+
+	if req.MatchId != "" {
+		match, err := s.repo.FindMatch(req.MatchId)
+		if err != nil {
+			return nil, errors.New("match not found")
+		}
+		if !isMatchTeam(match, colorId) {
+			return nil, errors.New("your color is not playing in this match")
+		}
+		if match.IsDraw || match.WinnerId != nil || !time.Now().Before(match.StartTime) {
+			return nil, errors.New("team pool for this match is closed")
+		}
+	}
+
+	contribution, err := s.repo.Contribute(poolTarget(req.MatchId), colorId, userId, req.Amount, time.Now())
+	if err != nil {
+		s.log.Named("Contribute").Warn("Contribute", zap.Error(err),
+			zap.String("user_id", userId),
+			zap.String("match_id", req.MatchId))
+		return nil, err
+	}
+
+	s.log.Named("Contribute").Info("Contributed to team pool",
+		zap.String("user_id", userId),
+		zap.String("target", contribution.Pool.Target),
+		zap.String("color_id", colorId),
+		zap.Float64("amount", req.Amount))
+
+	contributionDto := contributionToDto(contribution)
+	return &contributionDto, nil
+}
+
+// GetPools returns every color's pool for a match, or for the championship when matchId is empty
+func (s *teamPoolServiceImpl) GetPools(userId string, matchId string) ([]model.TeamPoolDto, error) {
+	target := poolTarget(matchId)
+
+	colors, err := s.repo.FindColors()
+	if err != nil {
+		s.log.Named("GetPools").Error("FindColors", zap.Error(err))
+		return nil, err
+	}
+	if matchId != "" {
+		match, err := s.repo.FindMatch(matchId)
+		if err != nil {
+			return nil, errors.New("match not found")
+		}
+
+		teams := make([]model.Color, 0, 2)
+		for _, color := range colors {
+			if isMatchTeam(match, color.Id) {
+				teams = append(teams, color)
+			}
+		}
+		colors = teams
+	}
+
+	pools, err := s.repo.FindPoolsByTarget(target)
+	if err != nil {
+		s.log.Named("GetPools").Error("FindPoolsByTarget", zap.Error(err))
+		return nil, err
+	}
+	contributions, err := s.repo.FindContributionsByUserId(userId, target)
+	if err != nil {
+		s.log.Named("GetPools").Error("FindContributionsByUserId", zap.Error(err))
+		return nil, err
+	}
+
+	myContributions := make(map[string]model.TeamPoolContribution, len(contributions))
+	for _, contribution := range contributions {
+		myContributions[contribution.Pool.ColorId] = contribution
+	}
+
+	// Colors nobody has backed yet are listed as empty open pools
+	poolDtos := make([]model.TeamPoolDto, 0, len(colors))
+	seen := make(map[string]bool, len(pools))
+	for i := range pools {
+		poolDto := poolToDto(&pools[i])
+		if contribution, ok := myContributions[poolDto.ColorId]; ok {
+			poolDto.MyContribution = contribution.Amount
+			poolDto.MyPayout = contribution.Payout
+		}
+		poolDtos = append(poolDtos, poolDto)
+		seen[poolDto.ColorId] = true
+	}
+	for _, color := range colors {
+		if !seen[color.Id] {
+			poolDtos = append(poolDtos, model.TeamPoolDto{
+				Target:     target,
+				ColorId:    color.Id,
+				ColorTitle: color.Title,
+				Status:     PoolOpen,
+			})
+		}
+	}
+
+	return poolDtos, nil
+}
+
+func (s *teamPoolServiceImpl) GetMyContributions(userId string) ([]model.TeamPoolContributionDto, error) {
+	contributions, err := s.repo.FindContributionsByUserId(userId, "")
+	if err != nil {
+		s.log.Named("GetMyContributions").Error("FindContributionsByUserId", zap.Error(err))
+		return nil, err
+	}
+
+	contributionDtos := make([]model.TeamPoolContributionDto, len(contributions))
+	for i := range contributions {
+		contributionDtos[i] = contributionToDto(&contributions[i])
+	}
+	return contributionDtos, nil
+}
+
+func (s *teamPoolServiceImpl) GetLeaderboard() ([]model.TeamPoolLeaderboardDto, error) {
+	leaderboard, err := s.repo.GetColorLeaderboard()
+	if err != nil {
+		s.log.Named("GetLeaderboard").Error("GetColorLeaderboard", zap.Error(err))
+		return nil, err
+	}
+	return leaderboard, nil
+}
+
+// SettleMatch pays out the pools of a match that has a winner or was drawn, settled pools are skipped
+func (s *teamPoolServiceImpl) SettleMatch(matchId string) error {
+	match, err := s.repo.FindMatch(matchId)
+	if err != nil {
+		s.log.Named("SettleMatch").Error("FindMatch", zap.Error(err))
+		return err
+	}
+
+	var winnerId string
+	switch {
+	case match.IsDraw:
+	case match.WinnerId != nil:
+		winnerId = *match.WinnerId
+	default:
+		return errors.New("match has no result yet")
+	}
+
+	var colorIds []string
+	for _, teamId := range []*string{match.TeamA_Id, match.TeamB_Id} {
+		if teamId != nil {
+			colorIds = append(colorIds, *teamId)
+		}
+	}
+
+	return s.settle(matchId, colorIds, winnerId)
+}
+
+// SettleChampionship pays out the championship pools to the winning color, an empty winner refunds them.
+// The championship stays closed afterwards, for colors that never had a pool too.
+func (s *teamPoolServiceImpl) SettleChampionship(winnerId string) error {
+	colors, err := s.repo.FindColors()
+	if err != nil {
+		s.log.Named("SettleChampionship").Error("FindColors", zap.Error(err))
+		return err
+	}
+	if winnerId != "" && !hasColor(colors, winnerId) {
+		return errors.New("color not found")
+	}
+
+	colorIds := make([]string, len(colors))
+	for i, color := range colors {
+		colorIds[i] = color.Id
+	}
+
+	return s.settle(ChampionshipTarget, colorIds, winnerId)
+}
+
+func (s *teamPoolServiceImpl) settle(target string, colorIds []string, winnerId string) error {
+	pools, err := s.repo.Settle(target, colorIds, winnerId, s.cfg.GetGame().TeamPoolHouseEdge, time.Now())
+	if err != nil {
+		s.log.Named("settle").Error("Settle", zap.Error(err), zap.String("target", target))
+		return err
+	}
+
+	for _, pool := range pools {
+		s.log.Named("settle").Info("Settled team pool",
+			zap.String("target", target),
+			zap.String("color_id", pool.ColorId),
+			zap.String("status", pool.Status),
+			zap.Float64("total_amount", pool.TotalAmount),
+			zap.Float64("payout", pool.Payout))
+	}
+	return nil
+}
+
+func isMatchTeam(match *model.Match, colorId string) bool {
+	return (match.TeamA_Id != nil && *match.TeamA_Id == colorId) ||
+		(match.TeamB_Id != nil && *match.TeamB_Id == colorId)
+}
+
+func hasColor(colors []model.Color, colorId string) bool {
+	for _, color := range colors {
+		if color.Id == colorId {
+			return true
+		}
+	}
+	return false
+}
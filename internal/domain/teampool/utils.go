@@ -0,0 +1,77 @@
+package teampool
+
+import (
+	"math"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+// ChampionshipTarget is the pool target backing a color for the overall championship
+const ChampionshipTarget = "championship"
+
+// Pool statuses
+const (
+	PoolOpen     = "open"
+	PoolWon      = "won"
+	PoolLost     = "lost"
+	PoolRefunded = "refunded"
+)
+
+// SettledPot is what the winning pool shares, its own coins back plus the losing pools minus the house edge,
+// so winners never get back less than they put in.
+// It is zero when no other pool holds coins, nothing was won so the winners are refunded instead.
+func SettledPot(pools []model.TeamPool, winner *model.TeamPool, houseEdge float64) float64 {
+	var losing float64
+	for i := range pools {
+		if &pools[i] != winner {
+			losing += pools[i].TotalAmount
+		}
+	}
+	if winner == nil || losing <= 0 {
+		return 0
+	}
+	return winner.TotalAmount + math.Floor(losing*(1-houseEdge)*100)/100
+}
+
+// ContributorShare is a contributor's cut of the pot in proportion to what they put into the winning pool
+func ContributorShare(pot float64, poolTotal float64, amount float64) float64 {
+	if poolTotal <= 0 {
+		return 0
+	}
+	return math.Floor(pot*amount/poolTotal*100) / 100
+}
+
+func poolTarget(matchId string) string {
+	if matchId == "" {
+		return ChampionshipTarget
+	}
+	return matchId
+}
+
+func poolToDto(pool *model.TeamPool) model.TeamPoolDto {
+	return model.TeamPoolDto{
+		Id:               pool.Id,
+		Target:           pool.Target,
+		ColorId:          pool.ColorId,
+		ColorTitle:       pool.Color.Title,
+		TotalAmount:      pool.TotalAmount,
+		ContributorCount: pool.ContributorCount,
+		Status:           pool.Status,
+		Payout:           pool.Payout,
+		SettledAt:        pool.SettledAt,
+	}
+}
+
+func contributionToDto(contribution *model.TeamPoolContribution) model.TeamPoolContributionDto {
+	return model.TeamPoolContributionDto{
+		PoolId:     contribution.PoolId,
+		Target:     contribution.Pool.Target,
+		ColorId:    contribution.Pool.ColorId,
+		ColorTitle: contribution.Pool.Color.Title,
+		Amount:     contribution.Amount,
+		Payout:     contribution.Payout,
+		Status:     contribution.Pool.Status,
+		CreatedAt:  contribution.CreatedAt,
+		UpdatedAt:  contribution.UpdatedAt,
+	}
+}
@@ -17,6 +17,38 @@ func (r *userRepositoryImpl) Create(user *model.User) error {
 	return r.db.Create(user).Error
 }
 
+// GetStartingCoins returns the balance new users join the active season with
+func (r *userRepositoryImpl) GetStartingCoins() (float64, error) {
+	var coins []float64
+	err := r.db.Model(&model.Season{}).
+		Where("status = ?", model.SeasonActive).
+		Limit(1).
+		Pluck("starting_coins", &coins).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(coins) == 0 {
+		return model.DefaultStartingCoins, nil
+	}
+	return coins[0], nil
+}
+
+// GetSignupRole returns the role granted to the email before its first login, USER when there is none
+func (r *userRepositoryImpl) GetSignupRole(email string) (string, error) {
+	var roles []string
+	err := r.db.Model(&model.SignupRole{}).
+		Where("email = ?", email).
+		Limit(1).
+		Pluck("role_id", &roles).Error
+	if err != nil {
+		return "", err
+	}
+	if len(roles) == 0 {
+		return model.RoleUser, nil
+	}
+	return roles[0], nil
+}
+
 func (r *userRepositoryImpl) GetById(id string) (*model.User, error) {
 	var user model.User
 	if err := r.db.Preload("Role").Where("id = ?", id).First(&user).Error; err != nil {
@@ -44,3 +76,8 @@ func (r *userRepositoryImpl) GetAll() ([]*model.User, error) {
 func (r *userRepositoryImpl) Update(user *model.User) error {
 	return r.db.Model(user).Where("id = ?", user.Id).Updates(user).Error
 }
+
+// UpdateLeaderboardVisibility is separate from Update because Updates skips false booleans
+func (r *userRepositoryImpl) UpdateLeaderboardVisibility(userId string, hidden bool) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userId).Update("hide_from_leaderboard", hidden).Error
+}
@@ -23,8 +23,8 @@ func (h *UserHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.Mi
 	router.Patch("/:id", h.UpdateUser)
 
 	// Admin routes
-	adminRouter := router.Group("/admin", mid.AdminMiddleware)
-	adminRouter.Patch("/:id", h.AdminUpdateUser)
+	adminRouter := router.Group("/admin")
+	adminRouter.Patch("/:id", mid.RequirePermission(model.PermUserWrite), h.AdminUpdateUser)
 }
 
 // @Summary Create a new user
@@ -110,6 +110,12 @@ func (h *UserHttpHandler) UpdateUser(c *fiber.Ctx) error {
 	if err := h.service.UpdateUser(&user); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	if updateUserDto.HideFromLeaderboard != nil {
+		if err := h.service.SetLeaderboardVisibility(profile.Id, *updateUserDto.HideFromLeaderboard); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		user.HideFromLeaderboard = *updateUserDto.HideFromLeaderboard
+	}
 	return c.JSON(user)
 }
 
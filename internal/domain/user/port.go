@@ -8,6 +8,9 @@ type UserRepository interface {
 	GetByEmail(email string) (*model.User, error)
 	GetAll() ([]*model.User, error)
 	Update(user *model.User) error
+	UpdateLeaderboardVisibility(userId string, hidden bool) error
+	GetStartingCoins() (float64, error)
+	GetSignupRole(email string) (string, error)
 }
 
 type UserService interface {
@@ -15,6 +18,7 @@ type UserService interface {
 	GetUser(id string) (*model.UserDto, error)
 	GetAllUsers() ([]*model.UserDto, error)
 	UpdateUser(userDto *model.UserDto) error
+	SetLeaderboardVisibility(userId string, hidden bool) error
 	AdminUpdateUser(userId string, userDto *model.UserDto) error
 	DeductCoin(userId string, amount float64) (float64, error)
 }
@@ -24,8 +24,14 @@ func NewUserService(repo UserRepository, db *gorm.DB, log *zap.Logger) UserServi
 }
 
 func (s *userServiceImpl) CreateUser(userDto *model.UserDto) error {
-	userDto.RemainingCoin = 888.00
-	err := s.repo.Create(ToUserEntity(userDto))
+	startingCoins, err := s.repo.GetStartingCoins()
+	if err != nil {
+		s.log.Named("CreateUser").Error("Failed to get starting coins", zap.Error(err))
+		return err
+	}
+
+	userDto.RemainingCoin = startingCoins
+	err = s.repo.Create(ToUserEntity(userDto))
 	if err != nil {
 		s.log.Named("CreateUser").Error("Failed to create user", zap.Error(err))
 		return err
@@ -51,6 +57,8 @@ func (s *userServiceImpl) GetUser(id string) (*model.UserDto, error) {
 		RemainingCoin: user.RemainingCoin,
 		NickName:      user.NickName,
 		GroupId:       user.GroupId,
+
+		HideFromLeaderboard: user.HideFromLeaderboard,
 	}, nil
 }
 
@@ -72,6 +80,8 @@ func (s *userServiceImpl) GetAllUsers() ([]*model.UserDto, error) {
 			NickName:      user.NickName,
 			GroupId:       user.GroupId,
 			CreatedAt:     user.CreatedAt,
+
+			HideFromLeaderboard: user.HideFromLeaderboard,
 		}
 	}
 
@@ -97,6 +107,17 @@ func (s *userServiceImpl) UpdateUser(userDto *model.UserDto) error {
 	return nil
 }
 
+func (s *userServiceImpl) SetLeaderboardVisibility(userId string, hidden bool) error {
+	err := s.repo.UpdateLeaderboardVisibility(userId, hidden)
+	if err != nil {
+		s.log.Named("SetLeaderboardVisibility").Error("Failed to update leaderboard visibility", zap.Error(err))
+		return err
+	}
+
+	s.log.Named("SetLeaderboardVisibility").Info("Leaderboard visibility updated", zap.String("user_id", userId), zap.Bool("hidden", hidden))
+	return nil
+}
+
 func (s *userServiceImpl) AdminUpdateUser(userId string, userDto *model.UserDto) error {
 	existed, err := s.repo.GetById(userId)
 	if err != nil {
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type walletRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) WalletRepository {
+	return &walletRepositoryImpl{db: db}
+}
+
+// LockUsers locks the users FOR UPDATE in ascending id order, so two transfers
+// between the same pair in opposite directions cannot deadlock
+func (r *walletRepositoryImpl) LockUsers(tx *gorm.DB, userIds ...string) (map[string]*model.User, error) {
+	ordered := append([]string(nil), userIds...)
+	sort.Strings(ordered)
+
+	users := make(map[string]*model.User, len(ordered))
+	for _, userId := range ordered {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", userId).
+			First(&user).Error; err != nil {
+			return nil, errors.New("user not found")
+		}
+		users[userId] = &user
+	}
+	return users, nil
+}
+
+func (r *walletRepositoryImpl) SumSent(tx *gorm.DB, userId string, since time.Time) (float64, error) {
+	var total float64
+	err := tx.Model(&model.CoinTransfer{}).
+		Where("sender_id = ? AND created_at >= ?", userId, since).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *walletRepositoryImpl) SumReceived(tx *gorm.DB, userId string, since time.Time) (float64, error) {
+	var total float64
+	err := tx.Model(&model.CoinTransfer{}).
+		Where("recipient_id = ? AND created_at >= ?", userId, since).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// CountBetween counts transfers between the two users in either direction
+func (r *walletRepositoryImpl) CountBetween(tx *gorm.DB, userId string, otherUserId string, since time.Time) (int64, error) {
+	var count int64
+	err := tx.Model(&model.CoinTransfer{}).
+		Where("((sender_id = ? AND recipient_id = ?) OR (sender_id = ? AND recipient_id = ?)) AND created_at >= ?",
+			userId, otherUserId, otherUserId, userId, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *walletRepositoryImpl) Create(tx *gorm.DB, transfer *model.CoinTransfer) error {
+	return tx.Create(transfer).Error
+}
+
+func (r *walletRepositoryImpl) FindUser(userId string) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("id = ?", userId).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *walletRepositoryImpl) FindByUserId(userId string, limit int, offset int) ([]model.CoinTransfer, error) {
+	var transfers []model.CoinTransfer
+	err := r.db.Preload("Sender").
+		Preload("Recipient").
+		Where("sender_id = ? OR recipient_id = ?", userId, userId).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
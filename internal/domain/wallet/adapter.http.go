@@ -0,0 +1,112 @@
+package wallet
+
+import (
+	"strconv"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/middleware"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WalletHttpHandler struct {
+	service WalletService
+}
+
+func NewWalletHttpHandler(service WalletService) *WalletHttpHandler {
+	return &WalletHttpHandler{service: service}
+}
+
+func (h *WalletHttpHandler) RegisterRoutes(router fiber.Router, mid *middleware.MiddlewareHttpHandler) {
+	router = router.Group("/wallet", mid.AuthMiddleware)
+
+	router.Post("/transfer", h.Transfer)
+	router.Get("/transfers", h.GetTransfers)
+	router.Get("/limits", h.GetLimits)
+}
+
+// @Summary Transfer coins
+// @Description Send coins to a member of your own group. Transfers are limited by daily send and receive caps, a minimum account age and a daily count between the same two users.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param request body model.TransferCoinRequest true "Recipient, amount and memo"
+// @Success 201 {object} model.CoinTransferDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /wallet/transfer [post]
+// @Security BearerAuth
+func (h *WalletHttpHandler) Transfer(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	var req model.TransferCoinRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse body",
+		})
+	}
+
+	transfer, err := h.service.Transfer(profile.Id, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(transfer)
+}
+
+// @Summary Get transfer history
+// @Description Get the coins the user sent and received, newest first
+// @Tags Wallet
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} model.CoinTransferDto
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /wallet/transfers [get]
+// @Security BearerAuth
+func (h *WalletHttpHandler) GetTransfers(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	transfers, err := h.service.GetTransfers(profile.Id, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get transfers",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(transfers)
+}
+
+// @Summary Get transfer limits
+// @Description Get the transfer limits and how much of today's send and receive caps the user has used
+// @Tags Wallet
+// @Produce json
+// @Success 200 {object} model.WalletLimitsDto
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /wallet/limits [get]
+// @Security BearerAuth
+func (h *WalletHttpHandler) GetLimits(c *fiber.Ctx) error {
+	profile := utils.GetUserProfileFromCtx(c)
+
+	limits, err := h.service.GetLimits(profile.Id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(limits)
+}
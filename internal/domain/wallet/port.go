@@ -0,0 +1,24 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type WalletService interface {
+	Transfer(senderId string, req *model.TransferCoinRequest) (*model.CoinTransferDto, error)
+	GetTransfers(userId string, limit int, offset int) ([]model.CoinTransferDto, error)
+	GetLimits(userId string) (*model.WalletLimitsDto, error)
+}
+
+type WalletRepository interface {
+	LockUsers(tx *gorm.DB, userIds ...string) (map[string]*model.User, error)
+	SumSent(tx *gorm.DB, userId string, since time.Time) (float64, error)
+	SumReceived(tx *gorm.DB, userId string, since time.Time) (float64, error)
+	CountBetween(tx *gorm.DB, userId string, otherUserId string, since time.Time) (int64, error)
+	Create(tx *gorm.DB, transfer *model.CoinTransfer) error
+	FindUser(userId string) (*model.User, error)
+	FindByUserId(userId string, limit int, offset int) ([]model.CoinTransfer, error)
+}
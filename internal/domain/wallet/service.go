@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/esc-chula/intania-888-backend/internal/domain/stakegame"
+	"github.com/esc-chula/intania-888-backend/internal/model"
+	"github.com/esc-chula/intania-888-backend/pkg/config"
+	"github.com/esc-chula/intania-888-backend/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type walletServiceImpl struct {
+	repo WalletRepository
+	db   *gorm.DB
+	cfg  config.Config
+	log  *zap.Logger
+}
+
+func NewWalletService(repo WalletRepository, db *gorm.DB, cfg config.Config, log *zap.Logger) WalletService {
+	return &walletServiceImpl{
+		repo: repo,
+		db:   db,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// Transfer sends coins to another member of the sender's group. Both users are locked
+// for the whole transaction so the daily caps cannot be raced by parallel transfers.
+func (s *walletServiceImpl) Transfer(senderId string, req *model.TransferCoinRequest) (*model.CoinTransferDto, error) {
+	limits := s.cfg.GetWallet()
+
+	if req.RecipientId == "" {
+		return nil, errors.New("recipient is required")
+	}
+	if req.RecipientId == senderId {
+		return nil, errors.New("cannot transfer coins to yourself")
+	}
+	if req.Amount < limits.TransferMinAmount {
+		return nil, fmt.Errorf("transfer amount must be at least %.2f coins", limits.TransferMinAmount)
+	}
+	if !hasCoinPrecision(req.Amount) {
+		return nil, errors.New("transfer amount cannot have more than 2 decimals")
+	}
+	if len(req.Memo) > 200 {
+		return nil, errors.New("memo cannot exceed 200 characters")
+	}
+
+	now := time.Now()
+	dayStart := utils.BangkokDayStart(now)
+	minCreatedAt := now.Add(-time.Duration(limits.TransferMinAccountAge) * time.Hour)
+
+	var transfer *model.CoinTransfer
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		users, err := s.repo.LockUsers(tx, senderId, req.RecipientId)
+		if err != nil {
+			return err
+		}
+		sender, recipient := users[senderId], users[req.RecipientId]
+
+		if !sameGroup(sender, recipient) {
+			return errors.New("you can only transfer coins to members of your group")
+		}
+		if sender.CreatedAt.After(minCreatedAt) {
+			return fmt.Errorf("your account must be at least %d hours old to transfer coins", limits.TransferMinAccountAge)
+		}
+		if recipient.CreatedAt.After(minCreatedAt) {
+			return fmt.Errorf("recipient account must be at least %d hours old to receive coins", limits.TransferMinAccountAge)
+		}
+
+		sent, err := s.repo.SumSent(tx, senderId, dayStart)
+		if err != nil {
+			return err
+		}
+		if sent+req.Amount > limits.TransferDailySendLimit {
+			return fmt.Errorf("daily send limit of %.2f coins exceeded, %.2f left today", limits.TransferDailySendLimit, limits.TransferDailySendLimit-sent)
+		}
+
+		received, err := s.repo.SumReceived(tx, req.RecipientId, dayStart)
+		if err != nil {
+			return err
+		}
+		if received+req.Amount > limits.TransferDailyReceiveLimit {
+			return errors.New("recipient has reached their daily receive limit")
+		}
+
+		// Repeated transfers between the same pair are how alt accounts funnel coins
+		pairCount, err := s.repo.CountBetween(tx, senderId, req.RecipientId, dayStart)
+		if err != nil {
+			return err
+		}
+		if pairCount >= int64(limits.TransferPairDailyLimit) {
+			return fmt.Errorf("only %d transfers per day are allowed between the same two users", limits.TransferPairDailyLimit)
+		}
+
+		if err := stakegame.Debit(tx, sender, req.Amount); err != nil {
+			return err
+		}
+		if err := stakegame.Credit(tx, recipient.Id, req.Amount); err != nil {
+			return err
+		}
+
+		transfer = &model.CoinTransfer{
+			Id:          uuid.NewString(),
+			SenderId:    senderId,
+			RecipientId: recipient.Id,
+			Amount:      req.Amount,
+			Memo:        req.Memo,
+			CreatedAt:   now,
+			Sender:      *sender,
+			Recipient:   *recipient,
+		}
+		return s.repo.Create(tx, transfer)
+	})
+	if err != nil {
+		s.log.Named("Transfer").Warn("Transfer failed", zap.Error(err),
+			zap.String("sender_id", senderId),
+			zap.String("recipient_id", req.RecipientId),
+			zap.Float64("amount", req.Amount))
+		return nil, err
+	}
+
+	s.log.Named("Transfer").Info("Transferred coins",
+		zap.String("transfer_id", transfer.Id),
+		zap.String("sender_id", senderId),
+		zap.String("recipient_id", req.RecipientId),
+		zap.Float64("amount", req.Amount))
+
+	transferDto := transferToDto(transfer, senderId)
+	return &transferDto, nil
+}
+
+func (s *walletServiceImpl) GetTransfers(userId string, limit int, offset int) ([]model.CoinTransferDto, error) {
+	transfers, err := s.repo.FindByUserId(userId, limit, offset)
+	if err != nil {
+		s.log.Named("GetTransfers").Error("FindByUserId", zap.Error(err))
+		return nil, err
+	}
+
+	transferDtos := make([]model.CoinTransferDto, len(transfers))
+	for i := range transfers {
+		transferDtos[i] = transferToDto(&transfers[i], userId)
+	}
+	return transferDtos, nil
+}
+
+// GetLimits returns the transfer limits and how much of today's caps the user has used
+func (s *walletServiceImpl) GetLimits(userId string) (*model.WalletLimitsDto, error) {
+	limits := s.cfg.GetWallet()
+	now := time.Now()
+	dayStart := utils.BangkokDayStart(now)
+
+	user, err := s.repo.FindUser(userId)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	sent, err := s.repo.SumSent(s.db, userId, dayStart)
+	if err != nil {
+		s.log.Named("GetLimits").Error("SumSent", zap.Error(err))
+		return nil, err
+	}
+	received, err := s.repo.SumReceived(s.db, userId, dayStart)
+	if err != nil {
+		s.log.Named("GetLimits").Error("SumReceived", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.WalletLimitsDto{
+		MinAmount:         limits.TransferMinAmount,
+		DailySendLimit:    limits.TransferDailySendLimit,
+		SentToday:         sent,
+		DailyReceiveLimit: limits.TransferDailyReceiveLimit,
+		ReceivedToday:     received,
+		PairDailyLimit:    limits.TransferPairDailyLimit,
+		CanTransferFrom:   user.CreatedAt.Add(time.Duration(limits.TransferMinAccountAge) * time.Hour),
+		ResetAt:           dayStart.AddDate(0, 0, 1),
+	}, nil
+}
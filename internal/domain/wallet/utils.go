@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"math"
+
+	"github.com/esc-chula/intania-888-backend/internal/model"
+)
+
+// Transfer directions as seen by the user listing their history
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+// hasCoinPrecision reports whether amount has at most two decimals
+func hasCoinPrecision(amount float64) bool {
+	return math.Abs(amount*100-math.Round(amount*100)) < 1e-6
+}
+
+func sameGroup(a *model.User, b *model.User) bool {
+	return a.GroupId != nil && b.GroupId != nil && *a.GroupId == *b.GroupId
+}
+
+func transferToDto(transfer *model.CoinTransfer, userId string) model.CoinTransferDto {
+	transferDto := model.CoinTransferDto{
+		Id:        transfer.Id,
+		Amount:    transfer.Amount,
+		Memo:      transfer.Memo,
+		CreatedAt: transfer.CreatedAt,
+	}
+
+	counterpart := &transfer.Recipient
+	transferDto.Direction = DirectionSent
+	if transfer.SenderId != userId {
+		counterpart = &transfer.Sender
+		transferDto.Direction = DirectionReceived
+	}
+	transferDto.CounterpartId = counterpart.Id
+	transferDto.CounterpartName = counterpart.Name
+	transferDto.CounterpartNickName = counterpart.NickName
+
+	return transferDto
+}
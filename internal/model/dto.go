@@ -11,6 +11,8 @@ type UserDto struct {
 	GroupId       *string   `json:"group_id"`
 	RemainingCoin float64   `json:"remaining_coin"`
 	CreatedAt     time.Time `json:"created_at"`
+
+	HideFromLeaderboard bool `json:"hide_from_leaderboard"`
 }
 
 type RoleDto struct {
@@ -32,9 +34,35 @@ type RefreshTokenDto struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// RefreshCacheDto is keyed by refresh token, every token rotated from one login shares the session
 type RefreshCacheDto struct {
-	UserId string
-	Role   string
+	UserId    string
+	Role      string
+	SessionId string
+}
+
+type SessionCacheDto struct {
+	Id           string
+	UserId       string
+	UserAgent    string
+	IpAddress    string
+	RefreshToken string
+	CreatedAt    time.Time
+	LastSeenAt   time.Time
+}
+
+type SessionDto struct {
+	Id         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IpAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	IsCurrent  bool      `json:"is_current"`
+}
+
+type AccessTokenClaimDto struct {
+	UserId    string
+	SessionId string
 }
 
 type ColorDto struct {
@@ -133,6 +161,92 @@ type DailyRewardCacheDto struct {
 	Reward float64
 }
 
+type DailyRewardStatusDto struct {
+	Date          string  `json:"date"`        // DD-MM-YYYY, Asia/Bangkok
+	BaseReward    float64 `json:"base_reward"` // configured amount for the date before the streak bonus
+	Multiplier    float64 `json:"multiplier"`
+	Reward        float64 `json:"reward"` // paid today, or payable if not claimed yet
+	Claimed       bool    `json:"claimed"`
+	Streak        int     `json:"streak"` // consecutive days claimed, 0 once a day is missed
+	LongestStreak int     `json:"longest_streak"`
+}
+
+// Slot machine configuration DTOs
+type SlotSymbolDto struct {
+	Symbol      string  `json:"symbol"`
+	Probability float64 `json:"probability"`
+}
+
+type SlotTierDto struct {
+	MinBalance float64         `json:"min_balance"` // applies when balance is above this amount
+	Symbols    []SlotSymbolDto `json:"symbols"`
+}
+
+type SlotPayRuleDto struct {
+	Name       string   `json:"name"`
+	Pattern    []string `json:"pattern"` // symbol, "*" for any symbol, "X" for a repeated symbol
+	Multiplier float64  `json:"multiplier"`
+	Action     string   `json:"action,omitempty"` // steal_token
+}
+
+type SlotConfigDto struct {
+	Tiers     []SlotTierDto    `json:"tiers"`
+	Paytable  []SlotPayRuleDto `json:"paytable"`
+	UpdatedBy string           `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+}
+
+// Provably fair slot DTOs
+type SlotSeedDto struct {
+	ServerSeedHash string    `json:"server_seed_hash"`
+	ClientSeed     string    `json:"client_seed"`
+	Nonce          int       `json:"nonce"` // nonce of the next spin
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type RevealedSlotSeedDto struct {
+	ServerSeed     string     `json:"server_seed"`
+	ServerSeedHash string     `json:"server_seed_hash"`
+	ClientSeed     string     `json:"client_seed"`
+	SpinCount      int        `json:"spin_count"`
+	RevealedAt     *time.Time `json:"revealed_at"`
+}
+
+type RotateSlotSeedRequest struct {
+	ClientSeed string `json:"client_seed" validate:"max=64"`
+}
+
+type RotateSlotSeedResponse struct {
+	Previous *RevealedSlotSeedDto `json:"previous,omitempty"`
+	Current  SlotSeedDto          `json:"current"`
+}
+
+type SlotSpinDto struct {
+	Id             string    `json:"id"`
+	ServerSeedHash string    `json:"server_seed_hash"`
+	ServerSeed     string    `json:"server_seed,omitempty"` // only revealed once the seed is rotated
+	ClientSeed     string    `json:"client_seed"`
+	Nonce          int       `json:"nonce"`
+	Reels          []string  `json:"reels"`
+	RuleName       string    `json:"rule_name,omitempty"`
+	SpendAmount    float64   `json:"spend_amount"`
+	Reward         float64   `json:"reward"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type SlotSpinVerifyDto struct {
+	SpinId         string          `json:"spin_id"`
+	ServerSeed     string          `json:"server_seed"`
+	ServerSeedHash string          `json:"server_seed_hash"`
+	ClientSeed     string          `json:"client_seed"`
+	Nonce          int             `json:"nonce"`
+	Symbols        []SlotSymbolDto `json:"symbols"`
+	Reels          []string        `json:"reels"`
+	ComputedReels  []string        `json:"computed_reels"`
+	HashMatches    bool            `json:"hash_matches"`
+	ReelsMatch     bool            `json:"reels_match"`
+}
+
 type UpdateUserDto struct {
 	Id       string  `json:"id"`
 	Email    string  `json:"email"`
@@ -140,6 +254,8 @@ type UpdateUserDto struct {
 	NickName *string `json:"nick_name"`
 	RoleId   string  `json:"role_id"`
 	GroupId  *string `json:"group_id"`
+
+	HideFromLeaderboard *bool `json:"hide_from_leaderboard"` // left unchanged when omitted
 }
 
 // Steal token DTOs
@@ -181,12 +297,21 @@ type UseStealTokenResponseDto struct {
 }
 
 type CreateMineGameRequest struct {
-	BetAmount float64 `json:"bet_amount" validate:"required,gte=1,lte=1000000"`
-	RiskLevel string  `json:"risk_level" validate:"required,oneof=low medium high"`
+	BetAmount  float64 `json:"bet_amount" validate:"required,gte=1,lte=1000000"`
+	RiskLevel  string  `json:"risk_level" validate:"omitempty,oneof=low medium high"` // preset used when bomb_count is not given
+	GridSize   int     `json:"grid_size" validate:"omitempty,min=2,max=8"`            // side length, defaults to 4
+	BombCount  int     `json:"bomb_count" validate:"omitempty,min=1"`
+	ClientSeed string  `json:"client_seed" validate:"max=64"`
+	Nonce      int     `json:"nonce" validate:"gte=0"`
+
+	// Optional targets that cash the game out automatically once reached
+	AutoCashoutMultiplier float64 `json:"auto_cashout_multiplier" validate:"omitempty,gt=1"`
+	AutoCashoutDiamonds   int     `json:"auto_cashout_diamonds" validate:"omitempty,min=1"`
 }
 
 type RevealMineTileRequest struct {
-	Index int `json:"index" validate:"required,min=0,max=15"`
+	Index   int   `json:"index" validate:"min=0"`
+	Indices []int `json:"indices" validate:"omitempty,max=64,dive,min=0"` // revealed in order instead of index when given
 }
 
 // Response DTOs
@@ -197,17 +322,45 @@ type MineTileDto struct {
 }
 
 type MineGameDto struct {
-	Id            string        `json:"id"`
-	UserId        string        `json:"user_id"`
-	BetAmount     float64       `json:"bet_amount"`
-	RiskLevel     string        `json:"risk_level"`
-	Grid          []MineTileDto `json:"grid"`
-	RevealedCount int           `json:"revealed_count"`
-	CurrentPayout float64       `json:"current_payout"`
-	Multiplier    float64       `json:"multiplier"`
-	Status        string        `json:"status"`
-	CreatedAt     time.Time     `json:"created_at"`
-	CompletedAt   *time.Time    `json:"completed_at,omitempty"`
+	Id                    string        `json:"id"`
+	UserId                string        `json:"user_id"`
+	BetAmount             float64       `json:"bet_amount"`
+	RiskLevel             string        `json:"risk_level"`
+	GridSize              int           `json:"grid_size"`
+	BombCount             int           `json:"bomb_count"`
+	HouseEdge             float64       `json:"house_edge"`
+	Grid                  []MineTileDto `json:"grid"`
+	RevealedCount         int           `json:"revealed_count"`
+	CurrentPayout         float64       `json:"current_payout"`
+	Multiplier            float64       `json:"multiplier"`
+	Status                string        `json:"status"`
+	CompletionReason      string        `json:"completion_reason,omitempty"`
+	ServerSeedHash        string        `json:"server_seed_hash,omitempty"`
+	ServerSeed            string        `json:"server_seed,omitempty"` // only revealed once the game is finished
+	ClientSeed            string        `json:"client_seed,omitempty"`
+	Nonce                 int           `json:"nonce"`
+	AutoCashoutMultiplier float64       `json:"auto_cashout_multiplier,omitempty"`
+	AutoCashoutDiamonds   int           `json:"auto_cashout_diamonds,omitempty"`
+	CreatedAt             time.Time     `json:"created_at"`
+	CompletedAt           *time.Time    `json:"completed_at,omitempty"`
+}
+
+type MineSeedDto struct {
+	ServerSeedHash string `json:"server_seed_hash"`
+}
+
+type MineGameVerifyDto struct {
+	GameId         string `json:"game_id"`
+	RiskLevel      string `json:"risk_level"`
+	GridSize       int    `json:"grid_size"`
+	BombCount      int    `json:"bomb_count"`
+	ServerSeed     string `json:"server_seed"`
+	ServerSeedHash string `json:"server_seed_hash"`
+	ClientSeed     string `json:"client_seed"`
+	Nonce          int    `json:"nonce"`
+	BombIndices    []int  `json:"bomb_indices"`
+	HashMatches    bool   `json:"hash_matches"`
+	GridMatches    bool   `json:"grid_matches"`
 }
 
 type MineGameStatsDto struct {
@@ -222,15 +375,409 @@ type MineGameStatsDto struct {
 }
 
 type MineGameHistoryDto struct {
-	GameId        string     `json:"game_id"`
-	BetAmount     float64    `json:"bet_amount"`
-	RiskLevel     string     `json:"risk_level"`
-	Status        string     `json:"status"`
-	FinalPayout   float64    `json:"final_payout"`
-	Multiplier    float64    `json:"multiplier"`
-	RevealedCount int        `json:"revealed_count"`
-	CreatedAt     time.Time  `json:"created_at"`
-	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	GameId           string     `json:"game_id"`
+	BetAmount        float64    `json:"bet_amount"`
+	RiskLevel        string     `json:"risk_level"`
+	GridSize         int        `json:"grid_size"`
+	BombCount        int        `json:"bomb_count"`
+	Status           string     `json:"status"`
+	CompletionReason string     `json:"completion_reason,omitempty"`
+	FinalPayout      float64    `json:"final_payout"`
+	Multiplier       float64    `json:"multiplier"`
+	RevealedCount    int        `json:"revealed_count"`
+	CreatedAt        time.Time  `json:"created_at"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+}
+
+type NotificationDto struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NotificationListDto struct {
+	Notifications []*NotificationDto `json:"notifications"`
+	UnreadCount   int64              `json:"unread_count"`
+}
+
+type MineReplayStepDto struct {
+	Step        int       `json:"step"`
+	TileIndex   int       `json:"tile_index"`
+	TileType    string    `json:"tile_type"` // diamond, bomb
+	Multiplier  float64   `json:"multiplier"`
+	PayoutAtHit float64   `json:"payout_at_hit"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type MineGameReplayDto struct {
+	GameId           string              `json:"game_id"`
+	BetAmount        float64             `json:"bet_amount"`
+	GridSize         int                 `json:"grid_size"`
+	BombCount        int                 `json:"bomb_count"`
+	Status           string              `json:"status"`
+	CompletionReason string              `json:"completion_reason,omitempty"`
+	FinalPayout      float64             `json:"final_payout"`
+	Multiplier       float64             `json:"multiplier"`
+	Steps            []MineReplayStepDto `json:"steps"`
+	FinalGrid        []MineTileDto       `json:"final_grid,omitempty"` // only for finished games
+}
+
+type MineLeaderboardEntryDto struct {
+	Rank     int     `json:"rank"`
+	UserId   string  `json:"user_id"`
+	Name     string  `json:"name"`
+	NickName *string `json:"nick_name"`
+	ColorId  *string `json:"color_id"`
+	Value    float64 `json:"value"`
+}
+
+type MineLeaderboardDto struct {
+	Type        string                    `json:"type"`   // biggest_cashout, highest_multiplier, net_profit, longest_streak
+	Period      string                    `json:"period"` // day, week, month, all
+	ColorId     string                    `json:"color_id,omitempty"`
+	Entries     []MineLeaderboardEntryDto `json:"entries"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+}
+
+type PlaceCrashBetRequest struct {
+	BetAmount   float64 `json:"bet_amount" validate:"required,gte=1,lte=1000000"`
+	AutoCashout float64 `json:"auto_cashout" validate:"omitempty,gt=1"` // cash out automatically at this multiplier
+}
+
+type CrashBetDto struct {
+	Id                string     `json:"id"`
+	RoundId           string     `json:"round_id"`
+	UserId            string     `json:"user_id"`
+	Name              string     `json:"name,omitempty"`
+	NickName          *string    `json:"nick_name,omitempty"`
+	BetAmount         float64    `json:"bet_amount"`
+	AutoCashout       float64    `json:"auto_cashout,omitempty"`
+	Status            string     `json:"status"`
+	CashoutMultiplier float64    `json:"cashout_multiplier,omitempty"`
+	Payout            float64    `json:"payout"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CashedOutAt       *time.Time `json:"cashed_out_at,omitempty"`
+}
+
+type CrashRoundDto struct {
+	Id             string        `json:"id"`
+	Status         string        `json:"status"`
+	ServerSeedHash string        `json:"server_seed_hash"`
+	ServerSeed     string        `json:"server_seed,omitempty"` // only revealed once the round has crashed
+	CrashPoint     float64       `json:"crash_point,omitempty"` // only revealed once the round has crashed
+	Multiplier     float64       `json:"multiplier"`            // live multiplier at server_time
+	BettingEndsAt  time.Time     `json:"betting_ends_at"`
+	StartedAt      *time.Time    `json:"started_at,omitempty"`
+	CrashedAt      *time.Time    `json:"crashed_at,omitempty"`
+	ServerTime     time.Time     `json:"server_time"`
+	Bets           []CrashBetDto `json:"bets,omitempty"`
+}
+
+type CrashRoundVerifyDto struct {
+	RoundId            string  `json:"round_id"`
+	ServerSeed         string  `json:"server_seed"`
+	ServerSeedHash     string  `json:"server_seed_hash"`
+	HouseEdge          float64 `json:"house_edge"`
+	CrashPoint         float64 `json:"crash_point"`
+	ComputedCrashPoint float64 `json:"computed_crash_point"`
+	HashMatches        bool    `json:"hash_matches"`
+	Verified           bool    `json:"verified"`
+}
+
+type CreateCoinflipRequest struct {
+	BetAmount  float64 `json:"bet_amount" validate:"required,gte=1,lte=1000000"`
+	Side       string  `json:"side" validate:"required,oneof=heads tails"`
+	ClientSeed string  `json:"client_seed" validate:"max=64"`
+}
+
+type CreateDiceRequest struct {
+	BetAmount  float64 `json:"bet_amount" validate:"required,gte=1,lte=1000000"`
+	Target     float64 `json:"target" validate:"required,gt=0,lt=100"` // roll is compared against this, 2 decimals
+	Direction  string  `json:"direction" validate:"required,oneof=over under"`
+	ClientSeed string  `json:"client_seed" validate:"max=64"`
+}
+
+type GameRoundDto struct {
+	Id             string    `json:"id"`
+	Game           string    `json:"game"`
+	BetAmount      float64   `json:"bet_amount"`
+	Choice         string    `json:"choice"`
+	Result         string    `json:"result"`
+	Won            bool      `json:"won"`
+	Multiplier     float64   `json:"multiplier"`
+	Payout         float64   `json:"payout"`
+	ServerSeed     string    `json:"server_seed,omitempty"` // empty until the seed is rotated
+	ServerSeedHash string    `json:"server_seed_hash"`
+	ClientSeed     string    `json:"client_seed"`
+	Nonce          int       `json:"nonce"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Provably fair coin-flip and dice DTOs
+type GameSeedDto struct {
+	Game           string    `json:"game"`
+	ServerSeedHash string    `json:"server_seed_hash"`
+	ClientSeed     string    `json:"client_seed"`
+	Nonce          int       `json:"nonce"` // nonce of the next round
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type RevealedGameSeedDto struct {
+	ServerSeed     string     `json:"server_seed"`
+	ServerSeedHash string     `json:"server_seed_hash"`
+	ClientSeed     string     `json:"client_seed"`
+	RoundCount     int        `json:"round_count"`
+	RevealedAt     *time.Time `json:"revealed_at"`
+}
+
+type RotateGameSeedRequest struct {
+	ClientSeed string `json:"client_seed" validate:"max=64"`
+}
+
+type RotateGameSeedResponse struct {
+	Previous *RevealedGameSeedDto `json:"previous,omitempty"`
+	Current  GameSeedDto          `json:"current"`
+}
+
+type GameStatsDto struct {
+	Game          string  `json:"game"`
+	TotalGames    int     `json:"total_games"`
+	GamesWon      int     `json:"games_won"`
+	GamesLost     int     `json:"games_lost"`
+	TotalWagered  float64 `json:"total_wagered"`
+	TotalWinnings float64 `json:"total_winnings"`
+	BiggestWin    float64 `json:"biggest_win"`
+	NetProfit     float64 `json:"net_profit"`
+	WinRate       float64 `json:"win_rate"`
+}
+
+type QuestDto struct {
+	Id          string     `json:"id"`
+	Title       string     `json:"title" validate:"required,max=200"`
+	Description string     `json:"description"`
+	GoalType    string     `json:"goal_type" validate:"required,oneof=place_bills win_accumulator mines_multiplier slot_spins bet_all_sport_types"`
+	Target      int        `json:"target" validate:"gte=0"`    // 0 on bet_all_sport_types means every sport type
+	Threshold   float64    `json:"threshold" validate:"gte=0"` // minimum lines or multiplier, depending on the goal
+	RewardCoins float64    `json:"reward_coins" validate:"gte=0"`
+	RewardBadge string     `json:"reward_badge" validate:"max=100"`
+	IsActive    bool       `json:"is_active"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+}
+
+type UserQuestDto struct {
+	QuestDto
+	Progress    int        `json:"progress"`
+	Completed   bool       `json:"completed"`
+	Claimed     bool       `json:"claimed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
+}
+
+type UserBadgeDto struct {
+	Badge     string    `json:"badge"`
+	QuestId   *string   `json:"quest_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LotteryPrizeTierDto struct {
+	PotShare    float64 `json:"pot_share"`    // share of the pot, 0 to 1
+	FixedAmount float64 `json:"fixed_amount"` // coins paid on top of the pot share
+}
+
+type CreateLotteryRequest struct {
+	Title             string                `json:"title" validate:"required,max=200"`
+	TicketPrice       float64               `json:"ticket_price" validate:"required,gt=0"`
+	PotRate           float64               `json:"pot_rate" validate:"gte=0,lte=1"`
+	MaxTicketsPerUser int                   `json:"max_tickets_per_user" validate:"gte=0"`
+	Prizes            []LotteryPrizeTierDto `json:"prizes" validate:"required,min=1"`
+	SalesStartAt      time.Time             `json:"sales_start_at" validate:"required"`
+	SalesEndAt        time.Time             `json:"sales_end_at" validate:"required"`
+	DrawAt            time.Time             `json:"draw_at" validate:"required"`
+}
+
+type BuyLotteryTicketsRequest struct {
+	Quantity int `json:"quantity" validate:"required,min=1,max=100"`
+}
+
+type LotteryDto struct {
+	Id                string                `json:"id"`
+	Title             string                `json:"title"`
+	TicketPrice       float64               `json:"ticket_price"`
+	PotRate           float64               `json:"pot_rate"`
+	Pot               float64               `json:"pot"`
+	MaxTicketsPerUser int                   `json:"max_tickets_per_user"`
+	Prizes            []LotteryPrizeTierDto `json:"prizes"`
+	TicketCount       int                   `json:"ticket_count"`
+	Status            string                `json:"status"`
+	ServerSeedHash    string                `json:"server_seed_hash"`
+	ServerSeed        string                `json:"server_seed,omitempty"` // only revealed once drawn
+	SalesStartAt      time.Time             `json:"sales_start_at"`
+	SalesEndAt        time.Time             `json:"sales_end_at"`
+	DrawAt            time.Time             `json:"draw_at"`
+	DrawnAt           *time.Time            `json:"drawn_at,omitempty"`
+}
+
+type LotteryTicketDto struct {
+	LotteryId string    `json:"lottery_id"`
+	Number    int       `json:"number"`
+	Rank      int       `json:"rank,omitempty"`
+	Prize     float64   `json:"prize"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LotteryWinnerDto struct {
+	Rank     int     `json:"rank"`
+	Number   int     `json:"number"`
+	UserId   string  `json:"user_id"`
+	Name     string  `json:"name"`
+	NickName *string `json:"nick_name"`
+	Prize    float64 `json:"prize"`
+}
+
+type LotteryResultDto struct {
+	Lottery        LotteryDto         `json:"lottery"`
+	ClientSeed     string             `json:"client_seed"` // lottery id and final ticket count, fixed only once sales close
+	WinningNumbers []int              `json:"winning_numbers"`
+	Winners        []LotteryWinnerDto `json:"winners"`
+}
+
+type ContributeTeamPoolRequest struct {
+	MatchId string  `json:"match_id"` // empty to back the color for the overall championship
+	Amount  float64 `json:"amount" validate:"required,gte=1,lte=1000000"`
+}
+
+type SettleChampionshipRequest struct {
+	WinnerId string `json:"winner_id"` // color id, empty refunds every championship pool
+}
+
+type TeamPoolDto struct {
+	Id               string     `json:"id,omitempty"` // empty until someone contributes
+	Target           string     `json:"target"`
+	ColorId          string     `json:"color_id"`
+	ColorTitle       string     `json:"color_title"`
+	TotalAmount      float64    `json:"total_amount"`
+	ContributorCount int        `json:"contributor_count"`
+	Status           string     `json:"status"`
+	Payout           float64    `json:"payout"`
+	MyContribution   float64    `json:"my_contribution"`
+	MyPayout         float64    `json:"my_payout"`
+	SettledAt        *time.Time `json:"settled_at,omitempty"`
+}
+
+type TeamPoolContributionDto struct {
+	PoolId     string    `json:"pool_id"`
+	Target     string    `json:"target"`
+	ColorId    string    `json:"color_id"`
+	ColorTitle string    `json:"color_title"`
+	Amount     float64   `json:"amount"`
+	Payout     float64   `json:"payout"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type TeamPoolLeaderboardDto struct {
+	ColorId          string  `json:"color_id"`
+	Title            string  `json:"title"`
+	TotalContributed float64 `json:"total_contributed"`
+	TotalPayout      float64 `json:"total_payout"`
+	NetProfit        float64 `json:"net_profit"`
+	PoolsWon         int     `json:"pools_won"`
+	PoolsSettled     int     `json:"pools_settled"`
+	Contributors     int     `json:"contributors"`
+}
+
+type PlayerLeaderboardEntryDto struct {
+	Rank     int     `json:"rank"`
+	UserId   string  `json:"user_id"`
+	Name     string  `json:"name"`
+	NickName *string `json:"nick_name"`
+	GroupId  *string `json:"group_id"`
+	ColorId  *string `json:"color_id"`
+	Value    float64 `json:"value"`
+}
+
+type PlayerLeaderboardDto struct {
+	Type    string                      `json:"type"`   // wealth, net_profit, win_rate
+	Period  string                      `json:"period"` // day, week, month, all
+	GroupId string                      `json:"group_id,omitempty"`
+	ColorId string                      `json:"color_id,omitempty"`
+	Entries []PlayerLeaderboardEntryDto `json:"entries"`
+	Me      *PlayerLeaderboardEntryDto  `json:"me,omitempty"` // the caller's own rank, absent when unranked or hidden
+}
+
+type TransferCoinRequest struct {
+	RecipientId string  `json:"recipient_id" validate:"required"`
+	Amount      float64 `json:"amount" validate:"required,gt=0"`
+	Memo        string  `json:"memo" validate:"max=200"`
+}
+
+type CoinTransferDto struct {
+	Id                  string    `json:"id"`
+	Direction           string    `json:"direction"` // sent, received
+	CounterpartId       string    `json:"counterpart_id"`
+	CounterpartName     string    `json:"counterpart_name"`
+	CounterpartNickName *string   `json:"counterpart_nick_name"`
+	Amount              float64   `json:"amount"`
+	Memo                string    `json:"memo"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type WalletLimitsDto struct {
+	MinAmount         float64   `json:"min_amount"`
+	DailySendLimit    float64   `json:"daily_send_limit"`
+	SentToday         float64   `json:"sent_today"`
+	DailyReceiveLimit float64   `json:"daily_receive_limit"`
+	ReceivedToday     float64   `json:"received_today"`
+	PairDailyLimit    int       `json:"pair_daily_limit"`
+	CanTransferFrom   time.Time `json:"can_transfer_from"` // when the account is old enough to send or receive
+	ResetAt           time.Time `json:"reset_at"`
+}
+
+type StartSeasonRequest struct {
+	Name          string  `json:"name" validate:"required,max=100"`
+	StartingCoins float64 `json:"starting_coins" validate:"gte=0"` // 0 uses the default starting balance
+}
+
+type SeasonDto struct {
+	Id                string     `json:"id"`
+	Name              string     `json:"name"`
+	Status            string     `json:"status"`
+	StartingCoins     float64    `json:"starting_coins"`
+	TotalBills        int        `json:"total_bills"`
+	TotalMineGames    int        `json:"total_mine_games"`
+	TotalDailyRewards float64    `json:"total_daily_rewards"`
+	StartedAt         time.Time  `json:"started_at"`
+	EndedAt           *time.Time `json:"ended_at,omitempty"`
+}
+
+type SeasonStandingDto struct {
+	Rank         int    `json:"rank"`
+	ColorId      string `json:"color_id"`
+	Title        string `json:"title"`
+	TotalMatches int    `json:"total_matches"`
+	Won          int    `json:"won"`
+	Drawn        int    `json:"drawn"`
+	Lost         int    `json:"lost"`
+}
+
+type SeasonBalanceDto struct {
+	Rank     int     `json:"rank"`
+	UserId   string  `json:"user_id"`
+	Name     string  `json:"name"`
+	NickName *string `json:"nick_name"`
+	Coins    float64 `json:"coins"`
+}
+
+type SeasonResultDto struct {
+	Season    SeasonDto           `json:"season"`
+	Standings []SeasonStandingDto `json:"standings"`
+	Balances  []SeasonBalanceDto  `json:"balances"`     // top balances, players hidden from leaderboards are left out
+	Me        *SeasonBalanceDto   `json:"me,omitempty"` // the caller's own final balance
 }
 
 // External API DTOs
@@ -243,3 +790,27 @@ type DeductCoinResponse struct {
 	DeductedAmount   float64 `json:"deducted_amount"`
 	RemainingBalance float64 `json:"remaining_balance"`
 }
+
+type BanUserRequest struct {
+	UserId    string     `json:"user_id"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"` // omit for a permanent ban
+}
+
+type UserBanDto struct {
+	Id        string     `json:"id"`
+	UserId    string     `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Email     string     `json:"email"`
+	Reason    string     `json:"reason"`
+	IssuedBy  *string    `json:"issued_by"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	RevokedBy *string    `json:"revoked_by"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type BanCacheDto struct {
+	Banned bool
+}
@@ -13,6 +13,8 @@ type User struct {
 	CreatedAt     time.Time ``
 	UpdatedAt     time.Time ``
 
+	HideFromLeaderboard bool `gorm:"not null;default:false"`
+
 	Role  Role         `gorm:"foreignKey:RoleId"`
 	Group IntaniaGroup `gorm:"foreignKey:GroupId"`
 	Bills []BillHead   `gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
@@ -23,7 +25,15 @@ type Role struct {
 	CreatedAt time.Time ``
 	UpdatedAt time.Time ``
 
-	Users []User `gorm:"foreignKey:RoleId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Users       []User       `gorm:"foreignKey:RoleId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Permissions []Permission `gorm:"many2many:role_permissions;"`
+}
+
+type Permission struct {
+	Id          string    `gorm:"primaryKey;type:varchar(100)"`
+	Description string    `gorm:"type:varchar(200)"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time ``
 }
 
 type Color struct {
@@ -64,6 +74,7 @@ type Match struct {
 	WinnerId  *string   `gorm:"column:winner_id;type:varchar(100);"`
 	TypeId    string    `gorm:"column:type_id;type:varchar(100);not null"`
 	IsDraw    bool      `gorm:"column:is_draw;type:boolean;default:false"`
+	SeasonId  *string   `gorm:"column:season_id;type:varchar(100);index"`
 	StartTime time.Time `gorm:"column:start_time"`
 	EndTime   time.Time `gorm:"column:end_time"`
 	CreatedAt time.Time `gorm:"column:created_at"`
@@ -80,6 +91,7 @@ type BillHead struct {
 	Id        string    `gorm:"primaryKey;type:varchar(100)"`
 	Total     float64   `gorm:"type:decimal(10,2);not null"`
 	UserId    string    `gorm:"type:varchar(100);not null"`
+	SeasonId  *string   `gorm:"type:varchar(100);index"`
 	CreatedAt time.Time ``
 	UpdatedAt time.Time ``
 
@@ -144,12 +156,78 @@ type SportType struct {
 }
 
 type DailyReward struct {
-	Date      string    `gorm:"primaryKey;type:varchar(100)"` // DD-MM-YY eg. 31-10-24
+	Date      string    `gorm:"primaryKey;type:varchar(100)"` // DD-MM-YYYY eg. 31-10-2024, Asia/Bangkok
 	Reward    float64   `gorm:"type:decimal(10,2);not null"`
 	CreatedAt time.Time ``
 	UpdatedAt time.Time ``
 }
 
+type DailyRewardClaim struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	UserId    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_daily_reward_claims_user_date"`
+	Date      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_daily_reward_claims_user_date"` // DD-MM-YYYY, Asia/Bangkok
+	Reward    float64   `gorm:"type:decimal(10,2);not null"`
+	Streak    int       `gorm:"type:int;not null;default:1"`
+	SeasonId  *string   `gorm:"type:varchar(100);index"`
+	CreatedAt time.Time ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type DailyRewardStreak struct {
+	UserId        string    `gorm:"primaryKey;type:varchar(100)"`
+	CurrentStreak int       `gorm:"type:int;not null;default:0"`
+	LongestStreak int       `gorm:"type:int;not null;default:0"`
+	LastClaimDate string    `gorm:"type:varchar(100)"` // DD-MM-YYYY, Asia/Bangkok
+	LastReward    float64   `gorm:"type:decimal(10,2);default:0"`
+	CreatedAt     time.Time ``
+	UpdatedAt     time.Time ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type SlotConfig struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	Data      string    `gorm:"type:text;not null"` // JSON string of SlotConfigDto
+	UpdatedBy string    `gorm:"type:varchar(100)"`
+	CreatedAt time.Time ``
+	UpdatedAt time.Time ``
+}
+
+type SlotSeed struct {
+	Id             string     `gorm:"primaryKey;type:varchar(100)"`
+	UserId         string     `gorm:"type:varchar(100);not null;index;uniqueIndex:idx_slot_seeds_active_user,where:is_active = true"`
+	ServerSeed     string     `gorm:"type:varchar(100);not null"` // revealed once the seed is rotated
+	ServerSeedHash string     `gorm:"type:varchar(100);not null"`
+	ClientSeed     string     `gorm:"type:varchar(100);not null"`
+	Nonce          int        `gorm:"type:int;default:0"` // nonce of the next spin
+	IsActive       bool       `gorm:"type:boolean;default:true"`
+	RevealedAt     *time.Time ``
+	CreatedAt      time.Time  ``
+	UpdatedAt      time.Time  ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type SlotSpin struct {
+	Id             string    `gorm:"primaryKey;type:varchar(100)"`
+	UserId         string    `gorm:"type:varchar(100);not null;index"`
+	SeedId         string    `gorm:"type:varchar(100);not null;index"`
+	ServerSeedHash string    `gorm:"type:varchar(100);not null"`
+	ClientSeed     string    `gorm:"type:varchar(100);not null"`
+	Nonce          int       `gorm:"type:int;not null"`
+	Reels          string    `gorm:"type:varchar(100);not null"` // comma separated symbols
+	Symbols        string    `gorm:"type:text;not null"`         // JSON string of the tier symbols used for the spin
+	RuleName       string    `gorm:"type:varchar(100)"`
+	SpendAmount    float64   `gorm:"type:decimal(10,2);not null"`
+	Reward         float64   `gorm:"type:decimal(10,2);not null"`
+	SeasonId       *string   `gorm:"type:varchar(100);index"`
+	CreatedAt      time.Time ``
+
+	User User     `gorm:"foreignKey:UserId"`
+	Seed SlotSeed `gorm:"foreignKey:SeedId"`
+}
+
 type StealToken struct {
 	Id               string    `gorm:"primaryKey;type:varchar(100)"`
 	UserId           string    `gorm:"type:varchar(100);not null;index"`
@@ -163,25 +241,49 @@ type StealToken struct {
 	User User `gorm:"foreignKey:UserId"`
 }
 type MineGame struct {
-	Id            string     `gorm:"primaryKey;type:varchar(100)"`
-	UserId        string     `gorm:"type:varchar(100);not null"`
-	BetAmount     float64    `gorm:"type:decimal(10,2);not null"`
-	RiskLevel     string     `gorm:"type:varchar(20);not null"` // low, medium, high
-	Status        string     `gorm:"type:varchar(20);not null"` // active, won, lost, cashed_out
-	RevealedCount int        `gorm:"type:int;default:0"`
-	CurrentPayout float64    `gorm:"type:decimal(10,2);not null"`
-	Multiplier    float64    `gorm:"type:decimal(10,2);default:1.0"`
-	GridData      string     `gorm:"type:text;not null"` // JSON string of the grid
-	CreatedAt     time.Time  ``
-	UpdatedAt     time.Time  ``
-	CompletedAt   *time.Time ``
+	Id                    string     `gorm:"primaryKey;type:varchar(100)"`
+	UserId                string     `gorm:"type:varchar(100);not null"`
+	SeasonId              *string    `gorm:"type:varchar(100);index"`
+	BetAmount             float64    `gorm:"type:decimal(10,2);not null"`
+	RiskLevel             string     `gorm:"type:varchar(20);not null"` // low, medium, high, custom
+	GridSize              int        `gorm:"type:int;default:4"`        // side length of the board
+	BombCount             int        `gorm:"type:int;default:0"`        // 0 on games created before custom boards, use the risk level preset
+	HouseEdge             float64    `gorm:"type:decimal(5,4);default:0"`
+	Status                string     `gorm:"type:varchar(20);not null"` // active, won, lost, cashed_out, refunded, expired
+	CompletionReason      string     `gorm:"type:varchar(30)"`          // bomb, all_diamonds, cash_out, auto_cashout, idle_cashout, idle_refund, idle_forfeit
+	RevealedCount         int        `gorm:"type:int;default:0"`
+	CurrentPayout         float64    `gorm:"type:decimal(10,2);not null"`
+	Multiplier            float64    `gorm:"type:decimal(10,2);default:1.0"`
+	GridData              string     `gorm:"type:text;not null"` // JSON string of the grid
+	ServerSeed            string     `gorm:"type:varchar(100)"`  // revealed once the game is finished
+	ServerSeedHash        string     `gorm:"type:varchar(100)"`
+	ClientSeed            string     `gorm:"type:varchar(100)"`
+	Nonce                 int        `gorm:"type:int;default:0"`
+	AutoCashoutMultiplier float64    `gorm:"type:decimal(10,2);default:0"` // 0 when disabled
+	AutoCashoutDiamonds   int        `gorm:"type:int;default:0"`           // 0 when disabled
+	CreatedAt             time.Time  ``
+	UpdatedAt             time.Time  ``
+	CompletedAt           *time.Time ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type MineServerSeed struct {
+	Id             string    `gorm:"primaryKey;type:varchar(100)"`
+	UserId         string    `gorm:"type:varchar(100);not null;index"`
+	ServerSeed     string    `gorm:"type:varchar(100);not null"`
+	ServerSeedHash string    `gorm:"type:varchar(100);not null"`
+	GameId         *string   `gorm:"type:varchar(100)"` // null while the seed is committed but unused
+	CreatedAt      time.Time ``
+	UpdatedAt      time.Time ``
 
 	User User `gorm:"foreignKey:UserId"`
 }
 
 type MineGameHistory struct {
 	Id          string    `gorm:"primaryKey;type:varchar(100)"`
-	GameId      string    `gorm:"type:varchar(100);not null"`
+	GameId      string    `gorm:"type:varchar(100);not null;index"`
+	Step        int       `gorm:"type:int;not null;default:0"` // 1-based reveal order, 0 on rows written before replays
 	TileIndex   int       `gorm:"type:int;not null"`
 	TileType    string    `gorm:"type:varchar(20);not null"` // diamond, bomb
 	Multiplier  float64   `gorm:"type:decimal(10,2);not null"`
@@ -190,3 +292,271 @@ type MineGameHistory struct {
 
 	Game MineGame `gorm:"foreignKey:GameId"`
 }
+
+type Notification struct {
+	Id        string     `gorm:"primaryKey;type:varchar(100)"`
+	UserId    string     `gorm:"type:varchar(100);not null;index"`
+	Type      string     `gorm:"type:varchar(50);not null"` // e.g. mines_expired
+	Title     string     `gorm:"type:varchar(200);not null"`
+	Message   string     `gorm:"type:text;not null"`
+	ReadAt    *time.Time ``
+	CreatedAt time.Time  ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type CrashRound struct {
+	Id             string     `gorm:"primaryKey;type:varchar(100)"`
+	Status         string     `gorm:"type:varchar(20);not null;index"` // betting, running, crashed, cancelled
+	ServerSeed     string     `gorm:"type:varchar(100);not null"`      // revealed once the round has crashed
+	ServerSeedHash string     `gorm:"type:varchar(100);not null"`
+	CrashPoint     float64    `gorm:"type:decimal(10,2);not null"`
+	HouseEdge      float64    `gorm:"type:decimal(5,4);default:0"`
+	BettingEndsAt  time.Time  ``
+	StartedAt      *time.Time ``
+	CrashedAt      *time.Time ``
+	CreatedAt      time.Time  ``
+	UpdatedAt      time.Time  ``
+}
+
+type CrashBet struct {
+	Id                string     `gorm:"primaryKey;type:varchar(100)"`
+	RoundId           string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_crash_bets_round_user"`
+	UserId            string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_crash_bets_round_user;index"`
+	BetAmount         float64    `gorm:"type:decimal(10,2);not null"`
+	AutoCashout       float64    `gorm:"type:decimal(10,2);default:0"` // 0 when disabled
+	Status            string     `gorm:"type:varchar(20);not null"`    // active, cashed_out, lost, refunded
+	CashoutMultiplier float64    `gorm:"type:decimal(10,2);default:0"`
+	Payout            float64    `gorm:"type:decimal(10,2);default:0"`
+	CreatedAt         time.Time  ``
+	UpdatedAt         time.Time  ``
+	CashedOutAt       *time.Time ``
+
+	Round CrashRound `gorm:"foreignKey:RoundId"`
+	User  User       `gorm:"foreignKey:UserId"`
+}
+
+type GameRound struct {
+	Id             string    `gorm:"primaryKey;type:varchar(100)"`
+	UserId         string    `gorm:"type:varchar(100);not null;index:idx_game_rounds_user_game"`
+	Game           string    `gorm:"type:varchar(20);not null;index:idx_game_rounds_user_game"` // coinflip, dice
+	BetAmount      float64   `gorm:"type:decimal(10,2);not null"`
+	Choice         string    `gorm:"type:varchar(50);not null"`
+	Result         string    `gorm:"type:varchar(50);not null"`
+	Won            bool      `gorm:"not null"`
+	Multiplier     float64   `gorm:"type:decimal(10,4);not null"`
+	Payout         float64   `gorm:"type:decimal(10,2);not null"`
+	SeedId         *string   `gorm:"type:varchar(100);index"`    // null on rounds played before seeds were committed
+	ServerSeed     string    `gorm:"type:varchar(100);not null"` // only set on rounds played before seeds were committed
+	ServerSeedHash string    `gorm:"type:varchar(100);not null"`
+	ClientSeed     string    `gorm:"type:varchar(100);not null"`
+	Nonce          int       `gorm:"type:int;default:0"`
+	CreatedAt      time.Time ``
+
+	User User      `gorm:"foreignKey:UserId"`
+	Seed *GameSeed `gorm:"foreignKey:SeedId"`
+}
+
+type GameSeed struct {
+	Id             string     `gorm:"primaryKey;type:varchar(100)"`
+	UserId         string     `gorm:"type:varchar(100);not null;index;uniqueIndex:idx_game_seeds_active_user_game,where:is_active = true"`
+	Game           string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_game_seeds_active_user_game,where:is_active = true"` // coinflip, dice
+	ServerSeed     string     `gorm:"type:varchar(100);not null"`                                                                   // revealed once the seed is rotated
+	ServerSeedHash string     `gorm:"type:varchar(100);not null"`
+	ClientSeed     string     `gorm:"type:varchar(100);not null"`
+	Nonce          int        `gorm:"type:int;default:0"` // nonce of the next round
+	IsActive       bool       `gorm:"type:boolean;default:true"`
+	RevealedAt     *time.Time ``
+	CreatedAt      time.Time  ``
+	UpdatedAt      time.Time  ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type Quest struct {
+	Id          string     `gorm:"primaryKey;type:varchar(100)"`
+	Title       string     `gorm:"type:varchar(200);not null"`
+	Description string     `gorm:"type:text"`
+	GoalType    string     `gorm:"type:varchar(50);not null;index"` // place_bills, win_accumulator, mines_multiplier, slot_spins, bet_all_sport_types
+	Target      int        `gorm:"type:int;not null"`               // times the goal must be met, or sport types to cover
+	Threshold   float64    `gorm:"type:decimal(10,2);default:0"`    // minimum lines for win_accumulator, minimum multiplier for mines_multiplier
+	RewardCoins float64    `gorm:"type:decimal(10,2);default:0"`
+	RewardBadge string     `gorm:"type:varchar(100)"`
+	IsActive    bool       `gorm:"not null"`
+	StartsAt    *time.Time ``
+	EndsAt      *time.Time ``
+	CreatedAt   time.Time  ``
+	UpdatedAt   time.Time  ``
+}
+
+type QuestProgress struct {
+	Id          string     `gorm:"primaryKey;type:varchar(100)"`
+	QuestId     string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_quest_progress_quest_user"`
+	UserId      string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_quest_progress_quest_user;index"`
+	Progress    int        `gorm:"type:int;not null;default:0"`
+	Data        string     `gorm:"type:text"` // JSON list of sport type ids for bet_all_sport_types
+	CompletedAt *time.Time ``
+	ClaimedAt   *time.Time ``
+	CreatedAt   time.Time  ``
+	UpdatedAt   time.Time  ``
+
+	Quest Quest `gorm:"foreignKey:QuestId;constraint:OnDelete:CASCADE;"`
+	User  User  `gorm:"foreignKey:UserId"`
+}
+
+type UserBadge struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	UserId    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_user_badges_user_badge"`
+	Badge     string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_user_badges_user_badge"`
+	QuestId   *string   `gorm:"type:varchar(100)"`
+	CreatedAt time.Time ``
+
+	User User `gorm:"foreignKey:UserId"`
+}
+
+type Lottery struct {
+	Id                string     `gorm:"primaryKey;type:varchar(100)"`
+	Title             string     `gorm:"type:varchar(200);not null"`
+	TicketPrice       float64    `gorm:"type:decimal(10,2);not null"`
+	PotRate           float64    `gorm:"type:decimal(5,4);not null"` // share of ticket sales paid back through pot prizes
+	MaxTicketsPerUser int        `gorm:"type:int;default:0"`         // 0 for no limit
+	Prizes            string     `gorm:"type:text;not null"`         // JSON list of LotteryPrizeTierDto in rank order
+	TicketCount       int        `gorm:"type:int;not null;default:0"`
+	Status            string     `gorm:"type:varchar(20);not null;index"` // open, drawn
+	ServerSeed        string     `gorm:"type:varchar(100);not null"`      // revealed once drawn
+	ServerSeedHash    string     `gorm:"type:varchar(100);not null"`
+	WinningNumbers    string     `gorm:"type:text"` // JSON list of ticket numbers in rank order
+	SalesStartAt      time.Time  ``
+	SalesEndAt        time.Time  ``
+	DrawAt            time.Time  `gorm:"index"`
+	DrawnAt           *time.Time ``
+	CreatedAt         time.Time  ``
+	UpdatedAt         time.Time  ``
+}
+
+type LotteryTicket struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	LotteryId string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_lottery_tickets_lottery_number"`
+	Number    int       `gorm:"type:int;not null;uniqueIndex:idx_lottery_tickets_lottery_number"`
+	UserId    string    `gorm:"type:varchar(100);not null;index"`
+	Rank      int       `gorm:"type:int;default:0"` // prize rank starting at 1, 0 when the ticket did not win
+	Prize     float64   `gorm:"type:decimal(10,2);default:0"`
+	CreatedAt time.Time ``
+
+	Lottery Lottery `gorm:"foreignKey:LotteryId"`
+	User    User    `gorm:"foreignKey:UserId"`
+}
+
+type TeamPool struct {
+	Id               string     `gorm:"primaryKey;type:varchar(100)"`
+	Target           string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_team_pools_target_color"` // match id, or championship
+	ColorId          string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_team_pools_target_color"`
+	TotalAmount      float64    `gorm:"type:decimal(12,2);not null;default:0"`
+	ContributorCount int        `gorm:"type:int;not null;default:0"`
+	Status           string     `gorm:"type:varchar(20);not null;index"` // open, won, lost, refunded
+	Payout           float64    `gorm:"type:decimal(12,2);default:0"`    // paid out to the contributors once settled
+	SettledAt        *time.Time ``
+	CreatedAt        time.Time  ``
+	UpdatedAt        time.Time  ``
+
+	Color Color `gorm:"foreignKey:ColorId"`
+}
+
+type TeamPoolContribution struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	PoolId    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_team_pool_contributions_pool_user"`
+	UserId    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_team_pool_contributions_pool_user;index"`
+	Amount    float64   `gorm:"type:decimal(12,2);not null"`
+	Payout    float64   `gorm:"type:decimal(12,2);default:0"`
+	CreatedAt time.Time ``
+	UpdatedAt time.Time ``
+
+	Pool TeamPool `gorm:"foreignKey:PoolId"`
+	User User     `gorm:"foreignKey:UserId"`
+}
+
+type CoinTransfer struct {
+	Id          string    `gorm:"primaryKey;type:varchar(100)"`
+	SenderId    string    `gorm:"type:varchar(100);not null;index:idx_coin_transfers_sender_created"`
+	RecipientId string    `gorm:"type:varchar(100);not null;index:idx_coin_transfers_recipient_created"`
+	Amount      float64   `gorm:"type:decimal(10,2);not null"`
+	Memo        string    `gorm:"type:varchar(200)"`
+	CreatedAt   time.Time `gorm:"index:idx_coin_transfers_sender_created;index:idx_coin_transfers_recipient_created"`
+
+	Sender    User `gorm:"foreignKey:SenderId"`
+	Recipient User `gorm:"foreignKey:RecipientId"`
+}
+
+type Season struct {
+	Id                string     `gorm:"primaryKey;type:varchar(100)"`
+	Name              string     `gorm:"type:varchar(100);not null"`
+	Status            string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_seasons_active,where:status = 'active'"` // active, archived
+	StartingCoins     float64    `gorm:"type:decimal(10,2);not null"`
+	TotalBills        int        `gorm:"type:int;default:0"` // totals below are filled in when the season is archived
+	TotalMineGames    int        `gorm:"type:int;default:0"`
+	TotalDailyRewards float64    `gorm:"type:decimal(12,2);default:0"`
+	StartedAt         time.Time  ``
+	EndedAt           *time.Time ``
+	CreatedAt         time.Time  ``
+	UpdatedAt         time.Time  ``
+}
+
+type SeasonBalance struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	SeasonId  string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_season_balances_season_user"`
+	UserId    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_season_balances_season_user;index"`
+	Coins     float64   `gorm:"type:decimal(12,2);not null"`
+	Rank      int       `gorm:"type:int;not null"`
+	CreatedAt time.Time ``
+
+	Season Season `gorm:"foreignKey:SeasonId"`
+	User   User   `gorm:"foreignKey:UserId"`
+}
+
+type SeasonStanding struct {
+	Id           string    `gorm:"primaryKey;type:varchar(100)"`
+	SeasonId     string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_season_standings_season_color"`
+	ColorId      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_season_standings_season_color"`
+	TotalMatches int       `gorm:"type:int;not null"`
+	Won          int       `gorm:"type:int;not null"`
+	Drawn        int       `gorm:"type:int;not null"`
+	Lost         int       `gorm:"type:int;not null"`
+	Rank         int       `gorm:"type:int;not null"`
+	CreatedAt    time.Time ``
+
+	Season Season `gorm:"foreignKey:SeasonId"`
+	Color  Color  `gorm:"foreignKey:ColorId"`
+}
+
+type UserBan struct {
+	Id        string     `gorm:"primaryKey;type:varchar(100)"`
+	UserId    string     `gorm:"type:varchar(100);not null;index"`
+	Reason    string     `gorm:"type:varchar(500);not null"`
+	IssuedBy  *string    `gorm:"type:varchar(100)"` // nil for bans carried over from the old blacklist
+	ExpiresAt *time.Time ``                         // nil bans permanently
+	RevokedAt *time.Time ``
+	RevokedBy *string    `gorm:"type:varchar(100)"`
+	CreatedAt time.Time  ``
+	UpdatedAt time.Time  ``
+
+	User   User `gorm:"foreignKey:UserId"`
+	Issuer User `gorm:"foreignKey:IssuedBy"`
+}
+
+// SignupBan stops an email or Google account id from signing up, user bans only cover accounts that already exist
+type SignupBan struct {
+	Id        string    `gorm:"primaryKey;type:varchar(100)"`
+	Email     *string   `gorm:"type:varchar(100);uniqueIndex"`
+	UserId    *string   `gorm:"type:varchar(100);uniqueIndex"` // the Google account id new users are created with
+	Reason    string    `gorm:"type:varchar(500);not null"`
+	CreatedAt time.Time ``
+}
+
+// SignupRole gives users signing up with the email a role other than USER
+type SignupRole struct {
+	Email     string    `gorm:"primaryKey;type:varchar(100)"`
+	RoleId    string    `gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time ``
+
+	Role Role `gorm:"foreignKey:RoleId"`
+}
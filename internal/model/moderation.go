@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// ActiveBan is a SQL condition matching user_bans rows that are neither revoked nor expired
+const ActiveBan = "revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())"
+
+func (b *UserBan) IsActive(now time.Time) bool {
+	return b.RevokedAt == nil && (b.ExpiresAt == nil || b.ExpiresAt.After(now))
+}
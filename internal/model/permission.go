@@ -0,0 +1,51 @@
+package model
+
+// Permissions checked by RequirePermission, granted to roles through role_permissions
+const (
+	PermUserWrite       = "user:write"
+	PermUserBan         = "user:ban"
+	PermBillReadAll     = "bill:read_all"
+	PermMatchWrite      = "match:write"
+	PermMatchSettle     = "match:settle"
+	PermEventConfigure  = "event:configure"
+	PermQuestConfigure  = "quest:configure"
+	PermSeasonConfigure = "season:configure"
+)
+
+// Roles seeded by the migration
+const (
+	RoleUser          = "USER"
+	RoleAdmin         = "ADMIN"
+	RoleMatchOperator = "MATCH_OPERATOR"
+)
+
+// Permissions lists every permission with what it grants
+var Permissions = map[string]string{
+	PermUserWrite:       "Edit any user including role and coins",
+	PermUserBan:         "Ban and unban users",
+	PermBillReadAll:     "Read every user's bills",
+	PermMatchWrite:      "Create, edit and delete matches",
+	PermMatchSettle:     "Enter scores and settle match and team pool results",
+	PermEventConfigure:  "Configure daily rewards, slots and lotteries",
+	PermQuestConfigure:  "Create, edit and delete quests",
+	PermSeasonConfigure: "Archive the season and start the next one",
+}
+
+// RolePermissions is the permission set each seeded role is given
+var RolePermissions = map[string][]string{
+	RoleUser: {},
+	RoleAdmin: {
+		PermUserWrite,
+		PermUserBan,
+		PermBillReadAll,
+		PermMatchWrite,
+		PermMatchSettle,
+		PermEventConfigure,
+		PermQuestConfigure,
+		PermSeasonConfigure,
+	},
+	RoleMatchOperator: {
+		PermMatchWrite,
+		PermMatchSettle,
+	},
+}
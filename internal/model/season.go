@@ -0,0 +1,63 @@
+package model
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Season statuses, exactly one season is active at a time
+const (
+	SeasonActive   = "active"
+	SeasonArchived = "archived"
+)
+
+// DefaultStartingCoins is the balance every user starts a season with unless the admin picks another
+const DefaultStartingCoins = 888.0
+
+// InActiveSeason is a SQL condition matching rows of column stamped with the active season,
+// rows without a season match while no season has started yet
+func InActiveSeason(column string) string {
+	return fmt.Sprintf("%s IS NOT DISTINCT FROM (SELECT id FROM seasons WHERE status = '%s' LIMIT 1)", column, SeasonActive)
+}
+
+// stampSeason sets seasonId to the active season unless the caller already chose one
+func stampSeason(tx *gorm.DB, seasonId **string) error {
+	if *seasonId != nil {
+		return nil
+	}
+
+	var ids []string
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Model(&Season{}).
+		Where("status = ?", SeasonActive).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		*seasonId = &ids[0]
+	}
+	return nil
+}
+
+func (m *Match) BeforeCreate(tx *gorm.DB) error {
+	return stampSeason(tx, &m.SeasonId)
+}
+
+func (b *BillHead) BeforeCreate(tx *gorm.DB) error {
+	return stampSeason(tx, &b.SeasonId)
+}
+
+func (g *MineGame) BeforeCreate(tx *gorm.DB) error {
+	return stampSeason(tx, &g.SeasonId)
+}
+
+func (c *DailyRewardClaim) BeforeCreate(tx *gorm.DB) error {
+	return stampSeason(tx, &c.SeasonId)
+}
+
+func (s *SlotSpin) BeforeCreate(tx *gorm.DB) error {
+	return stampSeason(tx, &s.SeasonId)
+}
@@ -53,9 +53,102 @@ func (r *RedisClient) GetValue(key string, value interface{}) error {
 	return json.Unmarshal([]byte(v), value)
 }
 
+// GetDelValue reads and deletes key atomically so only one caller can consume it
+func (r *RedisClient) GetDelValue(key string, value interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	v, err := r.client.GetDel(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(v), value)
+}
+
 func (r *RedisClient) DeleteValue(key string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	return r.client.Del(ctx, key).Err()
 }
+
+// SetSortedSet replaces the sorted set at key with members scored by value
+func (r *RedisClient) SetSortedSet(key string, members map[string]float64, ttl int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	zs := make([]redis.Z, 0, len(members))
+	for member, score := range members {
+		zs = append(zs, redis.Z{Score: score, Member: member})
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(zs) > 0 {
+		pipe.ZAdd(ctx, key, zs...)
+		pipe.Expire(ctx, key, time.Duration(ttl)*time.Second)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetSortedSetRange returns members from start to stop by descending score, redis.Nil when key is missing
+func (r *RedisClient) GetSortedSetRange(key string, start int64, stop int64) ([]redis.Z, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exists, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, redis.Nil
+	}
+
+	return r.client.ZRevRangeWithScores(ctx, key, start, stop).Result()
+}
+
+// GetSortedSetRank returns the 0-based descending rank and score of member, redis.Nil when absent
+func (r *RedisClient) GetSortedSetRank(key string, member string) (int64, float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rank, err := r.client.ZRevRank(ctx, key, member).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	score, err := r.client.ZScore(ctx, key, member).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rank, score, nil
+}
+
+// AddSetMember adds member to the set at key and extends the set's ttl
+func (r *RedisClient) AddSetMember(key string, member string, ttl int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pipe := r.client.TxPipeline()
+	pipe.SAdd(ctx, key, member)
+	pipe.Expire(ctx, key, time.Duration(ttl)*time.Second)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisClient) GetSetMembers(key string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.client.SMembers(ctx, key).Result()
+}
+
+func (r *RedisClient) RemoveSetMember(key string, member string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.client.SRem(ctx, key, member).Err()
+}
@@ -8,6 +8,8 @@ type Config interface {
 	GetOAuth() OAuth
 	GetSwagger() Swagger
 	GetCors() Cors
+	GetGame() Game
+	GetWallet() Wallet
 }
 
 type Server struct {
@@ -57,3 +59,28 @@ type Swagger struct {
 	Username string `mapstructure:"swagger_username"`
 	Password string `mapstructure:"swagger_password"`
 }
+
+type Game struct {
+	MinesHouseEdge       float64 `mapstructure:"game_mines_house_edge"`
+	MinesIdleTimeout     int     `mapstructure:"game_mines_idle_timeout"`     // seconds without a move before an active game is settled
+	MinesSweepInterval   int     `mapstructure:"game_mines_sweep_interval"`   // seconds between idle game sweeps
+	MinesUntouchedPolicy string  `mapstructure:"game_mines_untouched_policy"` // refund or forfeit idle games without revealed tiles
+	MinesLeaderboardTTL  int     `mapstructure:"game_mines_leaderboard_ttl"`  // seconds a computed leaderboard is served from cache
+	CrashHouseEdge       float64 `mapstructure:"game_crash_house_edge"`
+	CrashBettingWindow   int     `mapstructure:"game_crash_betting_window"` // seconds players can join a round before it starts
+	CrashRoundDelay      int     `mapstructure:"game_crash_round_delay"`    // seconds between a crash and the next betting window
+	CrashEngineEnabled   bool    `mapstructure:"game_crash_engine_enabled"` // let this instance compete for the engine lock, only the holder runs rounds
+	CoinflipHouseEdge    float64 `mapstructure:"game_coinflip_house_edge"`
+	DiceHouseEdge        float64 `mapstructure:"game_dice_house_edge"`
+	DailyRewardDefault   float64 `mapstructure:"game_daily_reward_default"` // coins for dates without a configured reward
+	TeamPoolHouseEdge    float64 `mapstructure:"game_team_pool_house_edge"` // share of the losing pools kept by the house
+	LeaderboardTTL       int     `mapstructure:"game_leaderboard_ttl"`      // seconds a player ranking is served from its sorted set
+}
+
+type Wallet struct {
+	TransferMinAmount         float64 `mapstructure:"wallet_transfer_min_amount"`
+	TransferDailySendLimit    float64 `mapstructure:"wallet_transfer_daily_send_limit"`    // coins a user can send per Asia/Bangkok day
+	TransferDailyReceiveLimit float64 `mapstructure:"wallet_transfer_daily_receive_limit"` // coins a user can receive per Asia/Bangkok day
+	TransferMinAccountAge     int     `mapstructure:"wallet_transfer_min_account_age"`     // hours both accounts must exist before transferring
+	TransferPairDailyLimit    int     `mapstructure:"wallet_transfer_pair_daily_limit"`    // transfers per day between the same two users, either direction
+}
@@ -16,6 +16,8 @@ type viperConfig struct {
 	OAuth   `mapstructure:",squash"`
 	Swagger `mapstructure:",squash"`
 	Cors    `mapstructure:",squash"`
+	Game    `mapstructure:",squash"`
+	Wallet  `mapstructure:",squash"`
 }
 
 var (
@@ -39,6 +41,7 @@ func NewViperConfig() Config {
 
 		// Bind environment variables to config keys
 		bindEnvVars(v)
+		setDefaults(v)
 		v.AutomaticEnv()
 
 		if err := v.ReadInConfig(); err != nil {
@@ -56,6 +59,10 @@ func NewViperConfig() Config {
 			log.Fatalf("Unable to decode into struct, %v", err)
 		}
 
+		if cfg.MinesHouseEdge < 0 || cfg.MinesHouseEdge >= 1 {
+			log.Fatalf("GAME_MINES_HOUSE_EDGE must be in [0, 1), got %v", cfg.MinesHouseEdge)
+		}
+
 		instance = cfg
 	})
 
@@ -105,6 +112,14 @@ func (c *viperConfig) GetCors() Cors {
 	return c.Cors
 }
 
+func (c *viperConfig) GetGame() Game {
+	return c.Game
+}
+
+func (c *viperConfig) GetWallet() Wallet {
+	return c.Wallet
+}
+
 func bindEnvVars(v *viper.Viper) {
 	v.BindEnv("server_name", "SERVER_NAME")
 	v.BindEnv("server_env", "SERVER_ENV")
@@ -137,4 +152,48 @@ func bindEnvVars(v *viper.Viper) {
 	v.BindEnv("swagger_password", "SWAGGER_PASSWORD")
 
 	v.BindEnv("cors_allow_origins", "CORS_ALLOW_ORIGINS")
+
+	v.BindEnv("game_mines_house_edge", "GAME_MINES_HOUSE_EDGE")
+	v.BindEnv("game_mines_idle_timeout", "GAME_MINES_IDLE_TIMEOUT")
+	v.BindEnv("game_mines_sweep_interval", "GAME_MINES_SWEEP_INTERVAL")
+	v.BindEnv("game_mines_untouched_policy", "GAME_MINES_UNTOUCHED_POLICY")
+	v.BindEnv("game_mines_leaderboard_ttl", "GAME_MINES_LEADERBOARD_TTL")
+	v.BindEnv("game_crash_house_edge", "GAME_CRASH_HOUSE_EDGE")
+	v.BindEnv("game_crash_betting_window", "GAME_CRASH_BETTING_WINDOW")
+	v.BindEnv("game_crash_round_delay", "GAME_CRASH_ROUND_DELAY")
+	v.BindEnv("game_crash_engine_enabled", "GAME_CRASH_ENGINE_ENABLED")
+	v.BindEnv("game_coinflip_house_edge", "GAME_COINFLIP_HOUSE_EDGE")
+	v.BindEnv("game_dice_house_edge", "GAME_DICE_HOUSE_EDGE")
+	v.BindEnv("game_daily_reward_default", "GAME_DAILY_REWARD_DEFAULT")
+	v.BindEnv("game_team_pool_house_edge", "GAME_TEAM_POOL_HOUSE_EDGE")
+	v.BindEnv("game_leaderboard_ttl", "GAME_LEADERBOARD_TTL")
+
+	v.BindEnv("wallet_transfer_min_amount", "WALLET_TRANSFER_MIN_AMOUNT")
+	v.BindEnv("wallet_transfer_daily_send_limit", "WALLET_TRANSFER_DAILY_SEND_LIMIT")
+	v.BindEnv("wallet_transfer_daily_receive_limit", "WALLET_TRANSFER_DAILY_RECEIVE_LIMIT")
+	v.BindEnv("wallet_transfer_min_account_age", "WALLET_TRANSFER_MIN_ACCOUNT_AGE")
+	v.BindEnv("wallet_transfer_pair_daily_limit", "WALLET_TRANSFER_PAIR_DAILY_LIMIT")
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("game_mines_house_edge", 0.01)
+	v.SetDefault("game_mines_idle_timeout", 1800)
+	v.SetDefault("game_mines_sweep_interval", 60)
+	v.SetDefault("game_mines_untouched_policy", "refund")
+	v.SetDefault("game_mines_leaderboard_ttl", 60)
+	v.SetDefault("game_crash_house_edge", 0.01)
+	v.SetDefault("game_crash_betting_window", 10)
+	v.SetDefault("game_crash_round_delay", 3)
+	v.SetDefault("game_crash_engine_enabled", false)
+	v.SetDefault("game_coinflip_house_edge", 0.01)
+	v.SetDefault("game_dice_house_edge", 0.01)
+	v.SetDefault("game_daily_reward_default", 300)
+	v.SetDefault("game_team_pool_house_edge", 0)
+	v.SetDefault("game_leaderboard_ttl", 60)
+
+	v.SetDefault("wallet_transfer_min_amount", 10)
+	v.SetDefault("wallet_transfer_daily_send_limit", 1000)
+	v.SetDefault("wallet_transfer_daily_receive_limit", 2000)
+	v.SetDefault("wallet_transfer_min_account_age", 72)
+	v.SetDefault("wallet_transfer_pair_daily_limit", 3)
 }
@@ -24,6 +24,7 @@ func main() {
 	if err := db.AutoMigrate(
 		&model.User{},
 		&model.Role{},
+		&model.Permission{},
 		&model.SportType{},
 		&model.Color{},
 		&model.IntaniaGroup{},
@@ -33,10 +34,35 @@ func main() {
 		&model.GroupHead{},
 		&model.GroupLine{},
 		&model.DailyReward{},
+		&model.DailyRewardClaim{},
+		&model.DailyRewardStreak{},
+		&model.Lottery{},
+		&model.LotteryTicket{},
 		&model.StealToken{},
+		&model.SlotConfig{},
+		&model.SlotSeed{},
+		&model.SlotSpin{},
 		&model.GroupStage{},
 		&model.MineGame{},
+		&model.MineServerSeed{},
 		&model.MineGameHistory{},
+		&model.Notification{},
+		&model.CrashRound{},
+		&model.CrashBet{},
+		&model.GameSeed{},
+		&model.GameRound{},
+		&model.Quest{},
+		&model.QuestProgress{},
+		&model.UserBadge{},
+		&model.TeamPool{},
+		&model.TeamPoolContribution{},
+		&model.CoinTransfer{},
+		&model.Season{},
+		&model.SeasonBalance{},
+		&model.SeasonStanding{},
+		&model.UserBan{},
+		&model.SignupBan{},
+		&model.SignupRole{},
 	); err != nil {
 		log.Fatalf("Error during migration: %v", err)
 	}
@@ -77,8 +103,9 @@ func main() {
 	log.Println("Existing match-related data deleted successfully.")
 
 	roles := []model.Role{
-		{ID: "USER", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ID: "ADMIN", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: model.RoleUser, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: model.RoleAdmin, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: model.RoleMatchOperator, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
 	// Updated color assignments for 2025
@@ -358,9 +385,90 @@ func main() {
 	}
 
 	// Create or update roles (skip if already exists)
+	for id, description := range model.Permissions {
+		permission := model.Permission{Id: id}
+		if err := db.Where(permission).Assign(model.Permission{Description: description}).FirstOrCreate(&permission).Error; err != nil {
+			log.Printf("Warning: Error upserting permission %s: %v", id, err)
+		}
+	}
+
 	for _, role := range roles {
 		if err := db.Where(model.Role{ID: role.ID}).FirstOrCreate(&role).Error; err != nil {
 			log.Printf("Warning: Error upserting role %s: %v", role.ID, err)
+			continue
+		}
+
+		permissions := make([]model.Permission, 0, len(model.RolePermissions[role.ID]))
+		for _, id := range model.RolePermissions[role.ID] {
+			permissions = append(permissions, model.Permission{Id: id})
+		}
+		if err := db.Model(&role).Association("Permissions").Replace(permissions); err != nil {
+			log.Printf("Warning: Error assigning permissions to role %s: %v", role.ID, err)
+		}
+	}
+
+	// Admins used to be hard-coded by email in the admin middleware
+	legacyAdminEmails := []string{
+		"6633165121@student.chula.ac.th",
+		"6738086221@student.chula.ac.th",
+		"6633149121@student.chula.ac.th",
+	}
+	if err := db.Model(&model.User{}).Where("email IN ?", legacyAdminEmails).Update("role_id", model.RoleAdmin).Error; err != nil {
+		log.Printf("Warning: Error promoting legacy admins: %v", err)
+	}
+	// Admins who never logged in get the role when they sign up
+	for _, email := range legacyAdminEmails {
+		signupRole := model.SignupRole{Email: email, RoleId: model.RoleAdmin}
+		if err := db.Where(model.SignupRole{Email: email}).FirstOrCreate(&signupRole).Error; err != nil {
+			log.Printf("Warning: Error granting admin to %s: %v", email, err)
+		}
+	}
+
+	// Blacklisted users used to be hard-coded in the auth middleware and login
+	legacyBlacklistEmails := []string{
+		"6530162621@student.chula.ac.th",
+		"6633129621@student.chula.ac.th",
+		"6733023821@student.chula.ac.th",
+		"6630054621@student.chula.ac.th",
+		"6538004621@student.chula.ac.th",
+		"6733291621@student.chula.ac.th",
+		"6430039021@student.chula.ac.th",
+	}
+	legacyBlacklistIds := []string{
+		"115982048644097094953",
+		"101935624102444830754",
+	}
+	// Blacklisted accounts that never logged in have no user to ban, so they are kept from signing up instead
+	for _, email := range legacyBlacklistEmails {
+		signupBan := model.SignupBan{Id: uuid.NewString(), Email: &email, Reason: "Carried over from the hard-coded blacklist"}
+		if err := db.Where("email = ?", email).FirstOrCreate(&signupBan).Error; err != nil {
+			log.Printf("Warning: Error banning email %s: %v", email, err)
+		}
+	}
+	for _, id := range legacyBlacklistIds {
+		signupBan := model.SignupBan{Id: uuid.NewString(), UserId: &id, Reason: "Carried over from the hard-coded blacklist"}
+		if err := db.Where("user_id = ?", id).FirstOrCreate(&signupBan).Error; err != nil {
+			log.Printf("Warning: Error banning user id %s: %v", id, err)
+		}
+	}
+
+	var blacklistedUsers []model.User
+	if err := db.Where("email IN ? OR id IN ?", legacyBlacklistEmails, legacyBlacklistIds).Find(&blacklistedUsers).Error; err != nil {
+		log.Printf("Warning: Error finding blacklisted users: %v", err)
+	}
+	for _, user := range blacklistedUsers {
+		var activeBans int64
+		db.Model(&model.UserBan{}).Where("user_id = ? AND "+model.ActiveBan, user.Id).Count(&activeBans)
+		if activeBans > 0 {
+			continue
+		}
+		ban := model.UserBan{
+			Id:     uuid.NewString(),
+			UserId: user.Id,
+			Reason: "Carried over from the hard-coded blacklist",
+		}
+		if err := db.Create(&ban).Error; err != nil {
+			log.Printf("Warning: Error banning user %s: %v", user.Id, err)
 		}
 	}
 
@@ -379,6 +487,34 @@ func main() {
 		}
 	}
 
+	// Start the first season so the seeded matches belong to it
+	var seasonCount int64
+	db.Model(&model.Season{}).Count(&seasonCount)
+	if seasonCount == 0 {
+		season := model.Season{
+			Id:            uuid.NewString(),
+			Name:          "2025",
+			Status:        model.SeasonActive,
+			StartingCoins: model.DefaultStartingCoins,
+			StartedAt:     time.Now(),
+		}
+		if err := db.Create(&season).Error; err != nil {
+			log.Printf("Error creating season: %v", err)
+		}
+	}
+
+	// Rows from before seasons existed belong to the first one, or the active season filters would hide them
+	var activeSeason model.Season
+	if err := db.Where("status = ?", model.SeasonActive).First(&activeSeason).Error; err != nil {
+		log.Printf("Warning: Error finding active season: %v", err)
+	} else {
+		for _, table := range []string{"matches", "bill_heads", "mine_games", "slot_spins", "daily_reward_claims"} {
+			if err := db.Table(table).Where("season_id IS NULL").Update("season_id", activeSeason.Id).Error; err != nil {
+				log.Printf("Warning: Error backfilling season of %s: %v", table, err)
+			}
+		}
+	}
+
 	if err := db.Create(&sportTypes).Error; err != nil {
 		log.Printf("Error creating sport_types: %v", err)
 	}
@@ -2,10 +2,35 @@ package utils
 
 import "fmt"
 
-func ToAccessCacheKey(userId string) string {
-	return fmt.Sprintf("session:%v", userId)
+func ToAccessCacheKey(userId string, sessionId string) string {
+	return fmt.Sprintf("session:%v:%v", userId, sessionId)
 }
 
 func ToRefreshCacheKey(refreshToken string) string {
 	return fmt.Sprintf("refresh:%v", refreshToken)
 }
+
+// ToRotatedRefreshCacheKey marks a refresh token that was already exchanged
+func ToRotatedRefreshCacheKey(refreshToken string) string {
+	return fmt.Sprintf("refresh-rotated:%v", refreshToken)
+}
+
+// ToBanCacheKey caches whether a user is banned for the auth middleware
+func ToBanCacheKey(userId string) string {
+	return fmt.Sprintf("ban:%v", userId)
+}
+
+// ToRolePermissionsCacheKey caches the permissions granted to a role
+func ToRolePermissionsCacheKey(roleId string) string {
+	return fmt.Sprintf("role-permissions:%v", roleId)
+}
+
+// ToSessionCacheKey holds the device info and latest refresh token of a session
+func ToSessionCacheKey(sessionId string) string {
+	return fmt.Sprintf("session-info:%v", sessionId)
+}
+
+// ToSessionIndexCacheKey is the set of session ids of a user
+func ToSessionIndexCacheKey(userId string) string {
+	return fmt.Sprintf("sessions:%v", userId)
+}
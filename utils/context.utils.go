@@ -13,3 +13,8 @@ func GetUserProfileFromCtx(c *fiber.Ctx) *model.UserDto {
 	}
 	return userDto
 }
+
+func GetSessionIdFromCtx(c *fiber.Ctx) string {
+	sessionId, _ := c.Locals("session_id").(string)
+	return sessionId
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// GenerateServerSeed returns a random hex encoded server seed
+func GenerateServerSeed() (string, error) {
+	return randomHex(32)
+}
+
+// GenerateClientSeed returns a random hex encoded client seed for players who did not pick one
+func GenerateClientSeed() (string, error) {
+	return randomHex(16)
+}
+
+// HashServerSeed returns the SHA-256 commitment published before the seed is used
+func HashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// FairFloats derives count numbers in [0, 1) from HMAC-SHA256(serverSeed, "clientSeed:nonce:round").
+// Every 4 bytes of a digest become one number, and the round is bumped whenever a digest runs out.
+func FairFloats(serverSeed, clientSeed string, nonce int, count int) []float64 {
+	floats := make([]float64, 0, count)
+
+	for round := 0; len(floats) < count; round++ {
+		mac := hmac.New(sha256.New, []byte(serverSeed))
+		mac.Write([]byte(fmt.Sprintf("%s:%d:%d", clientSeed, nonce, round)))
+		digest := mac.Sum(nil)
+
+		for i := 0; i+4 <= len(digest) && len(floats) < count; i += 4 {
+			var f float64
+			divider := 256.0
+			for _, b := range digest[i : i+4] {
+				f += float64(b) / divider
+				divider *= 256
+			}
+			floats = append(floats, f)
+		}
+	}
+
+	return floats
+}
+
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
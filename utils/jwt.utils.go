@@ -30,9 +30,10 @@ func JwtParseToken(reqToken, secretKey string) (jwt.MapClaims, error) {
 	return claims, nil
 }
 
-func JwtSignAccessToken(userID, role, secretKey string, expiration int) (*string, error) {
+func JwtSignAccessToken(userID, sessionID, role, secretKey string, expiration int) (*string, error) {
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub":  userID,
+		"sid":  sessionID,
 		"exp":  time.Now().Add(time.Second * time.Duration(expiration)).Unix(),
 		"iat":  time.Now().Unix(),
 		"iss":  config.GetConfig().GetServer().Name,
@@ -1,64 +1,233 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"math/rand/v2"
+	"sort"
+	"strings"
 
 	"github.com/esc-chula/intania-888-backend/internal/model"
 )
 
-func GetRandomSlot(userDto *model.UserDto) string {
-	var probabilities map[string]float64
-
-	if userDto.RemainingCoin > 100000.00 {
-		probabilities = map[string]float64{
-			"🍇": 1.0 / 7.0,
-			"🍋": 1.0 / 7.0,
-			"🍎": 1.0 / 7.0,
-			"🍐": 1.0 / 7.0,
-			"🍊": 1.0 / 7.0,
-			"💰": 1.0 / 20.0,
-			"👽": 1.0 / 7.0,
-		}
-	} else if userDto.RemainingCoin > 50000.00 {
-		probabilities = map[string]float64{
-			"🍇": 1.0 / 7.0,
-			"🍋": 1.0 / 7.0,
-			"🍎": 1.0 / 7.0,
-			"🍐": 1.0 / 7.0,
-			"🍊": 1.0 / 7.0,
-			"💰": 1.0 / 10.0,
-			"👽": 1.0 / 7.0,
-		}
-	} else if userDto.RemainingCoin > 25000.00 {
-		probabilities = map[string]float64{
-			"🍇": 1.0 / 7.0,
-			"🍋": 1.0 / 7.0,
-			"🍎": 1.0 / 7.0,
-			"🍐": 1.0 / 7.0,
-			"🍊": 1.0 / 7.0,
-			"💰": 1.0 / 8.0,
-			"👽": 1.0 / 7.0,
-		}
-	} else {
-		probabilities = map[string]float64{
-			"🍇": 1.0 / 7.0,
-			"🍋": 1.0 / 7.0,
-			"🍎": 1.0 / 7.0,
-			"🍐": 1.0 / 7.0,
-			"🍊": 1.0 / 7.0,
-			"💰": 1.0 / 6.0,
-			"👽": 1.0 / 7.0,
+const (
+	SlotReelCount        = 3
+	SlotWildcard         = "*" // matches any symbol
+	SlotRepeated         = "X" // matches the same symbol at every "X" position
+	SlotActionStealToken = "steal_token"
+
+	slotProbabilityTolerance = 1e-6
+)
+
+// DefaultSlotConfig returns the built-in slot configuration used when none is stored
+func DefaultSlotConfig() *model.SlotConfigDto {
+	return &model.SlotConfigDto{
+		Tiers: []model.SlotTierDto{
+			newDefaultSlotTier(0, 1.0/6.0),
+			newDefaultSlotTier(25000, 1.0/8.0),
+			newDefaultSlotTier(50000, 1.0/10.0),
+			newDefaultSlotTier(100000, 1.0/20.0),
+		},
+		Paytable: []model.SlotPayRuleDto{
+			{Name: "3 aliens", Pattern: []string{"👽", "👽", "👽"}, Multiplier: 4.0, Action: SlotActionStealToken},
+			{Name: "3 gold", Pattern: []string{"💰", "💰", "💰"}, Multiplier: 10.0},
+			{Name: "3 matching", Pattern: []string{SlotRepeated, SlotRepeated, SlotRepeated}, Multiplier: 4.0},
+			{Name: "2 gold", Pattern: []string{"💰", "💰", SlotWildcard}, Multiplier: 3.0},
+			{Name: "1 gold + 2 matching", Pattern: []string{"💰", SlotRepeated, SlotRepeated}, Multiplier: 2.0},
+			{Name: "1 gold", Pattern: []string{"💰", SlotWildcard, SlotWildcard}, Multiplier: 1.5},
+			{Name: "2 matching", Pattern: []string{SlotRepeated, SlotRepeated, SlotWildcard}, Multiplier: 0.75},
+		},
+	}
+}
+
+// newDefaultSlotTier splits whatever is left after the gold symbol evenly among the other symbols
+func newDefaultSlotTier(minBalance float64, goldProbability float64) model.SlotTierDto {
+	others := []string{"🍇", "🍋", "🍎", "🍐", "🍊", "👽"}
+	symbols := make([]model.SlotSymbolDto, 0, len(others)+1)
+	for _, symbol := range others {
+		symbols = append(symbols, model.SlotSymbolDto{Symbol: symbol, Probability: (1 - goldProbability) / float64(len(others))})
+	}
+	symbols = append(symbols, model.SlotSymbolDto{Symbol: "💰", Probability: goldProbability})
+
+	return model.SlotTierDto{MinBalance: minBalance, Symbols: symbols}
+}
+
+// ValidateSlotConfig checks that every tier is a proper probability distribution and every paytable rule is usable
+func ValidateSlotConfig(cfg *model.SlotConfigDto) error {
+	if cfg == nil || len(cfg.Tiers) == 0 {
+		return errors.New("slot config must have at least one tier")
+	}
+
+	knownSymbols := make(map[string]bool)
+	tierBalances := make(map[float64]bool)
+	hasBaseTier := false
+
+	for i, tier := range cfg.Tiers {
+		if tier.MinBalance < 0 {
+			return fmt.Errorf("tier %d: min_balance cannot be negative", i)
+		}
+		if tierBalances[tier.MinBalance] {
+			return fmt.Errorf("tier %d: duplicated min_balance %.2f", i, tier.MinBalance)
+		}
+		tierBalances[tier.MinBalance] = true
+		if tier.MinBalance == 0 {
+			hasBaseTier = true
+		}
+
+		if len(tier.Symbols) == 0 {
+			return fmt.Errorf("tier %d: must have at least one symbol", i)
+		}
+
+		var sum float64
+		tierSymbols := make(map[string]bool)
+		for _, symbol := range tier.Symbols {
+			if symbol.Symbol == "" || symbol.Symbol == SlotWildcard || symbol.Symbol == SlotRepeated {
+				return fmt.Errorf("tier %d: invalid symbol %q", i, symbol.Symbol)
+			}
+			// Spin reels are stored comma separated
+			if strings.Contains(symbol.Symbol, ",") {
+				return fmt.Errorf("tier %d: symbol %q cannot contain a comma", i, symbol.Symbol)
+			}
+			if tierSymbols[symbol.Symbol] {
+				return fmt.Errorf("tier %d: duplicated symbol %q", i, symbol.Symbol)
+			}
+			if symbol.Probability < 0 || symbol.Probability > 1 {
+				return fmt.Errorf("tier %d: probability of %q must be between 0 and 1", i, symbol.Symbol)
+			}
+			tierSymbols[symbol.Symbol] = true
+			knownSymbols[symbol.Symbol] = true
+			sum += symbol.Probability
+		}
+
+		if math.Abs(sum-1) > slotProbabilityTolerance {
+			return fmt.Errorf("tier %d: probabilities sum to %.6f, expected 1", i, sum)
+		}
+	}
+
+	if !hasBaseTier {
+		return errors.New("slot config must have a tier with min_balance 0")
+	}
+
+	for i, rule := range cfg.Paytable {
+		if len(rule.Pattern) != SlotReelCount {
+			return fmt.Errorf("rule %d: pattern must have exactly %d symbols", i, SlotReelCount)
+		}
+		if rule.Multiplier < 0 {
+			return fmt.Errorf("rule %d: multiplier cannot be negative", i)
+		}
+		if rule.Action != "" && rule.Action != SlotActionStealToken {
+			return fmt.Errorf("rule %d: unknown action %q", i, rule.Action)
+		}
+		for _, symbol := range rule.Pattern {
+			if symbol != SlotWildcard && symbol != SlotRepeated && !knownSymbols[symbol] {
+				return fmt.Errorf("rule %d: unknown symbol %q", i, symbol)
+			}
 		}
 	}
 
-	random := rand.Float64()
+	return nil
+}
+
+// GetSlotTier returns the tier with the highest min balance that the given balance exceeds
+func GetSlotTier(cfg *model.SlotConfigDto, balance float64) *model.SlotTierDto {
+	tiers := make([]model.SlotTierDto, len(cfg.Tiers))
+	copy(tiers, cfg.Tiers)
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinBalance > tiers[j].MinBalance
+	})
+
+	for i := range tiers {
+		if balance > tiers[i].MinBalance || tiers[i].MinBalance == 0 {
+			return &tiers[i]
+		}
+	}
+	return &tiers[len(tiers)-1]
+}
+
+// PickSlotSymbol maps a random number in [0, 1) to a symbol, walking the symbols in their configured order
+func PickSlotSymbol(symbols []model.SlotSymbolDto, random float64) string {
 	var cumulative float64
+	last := ""
 
-	for symbol, probability := range probabilities {
-		cumulative += probability
+	for _, symbol := range symbols {
+		if symbol.Probability <= 0 {
+			continue
+		}
+		cumulative += symbol.Probability
+		last = symbol.Symbol
 		if random < cumulative {
-			return symbol
+			return symbol.Symbol
 		}
 	}
-	return "💰"
+
+	// floating point leftovers fall into the last symbol
+	return last
+}
+
+// GetRandomSlot spins a single reel using the tier matching the given balance
+func GetRandomSlot(cfg *model.SlotConfigDto, balance float64) string {
+	return PickSlotSymbol(GetSlotTier(cfg, balance).Symbols, rand.Float64())
+}
+
+// GetFairSlotReels derives every reel from the seeds and nonce, so a spin can be recomputed once the server seed is revealed
+func GetFairSlotReels(symbols []model.SlotSymbolDto, serverSeed, clientSeed string, nonce int) []string {
+	reels := make([]string, SlotReelCount)
+	for i, f := range FairFloats(serverSeed, clientSeed, nonce, SlotReelCount) {
+		reels[i] = PickSlotSymbol(symbols, f)
+	}
+	return reels
+}
+
+// MatchSlotPaytable returns the first paytable rule matching the reels in any order, or nil if none matches
+func MatchSlotPaytable(cfg *model.SlotConfigDto, reels []string) *model.SlotPayRuleDto {
+	for i := range cfg.Paytable {
+		if matchSlotPattern(cfg.Paytable[i].Pattern, reels) {
+			return &cfg.Paytable[i]
+		}
+	}
+	return nil
+}
+
+func matchSlotPattern(pattern []string, reels []string) bool {
+	if len(pattern) != len(reels) {
+		return false
+	}
+
+	used := make([]bool, len(reels))
+	var match func(position int, repeated string) bool
+	match = func(position int, repeated string) bool {
+		if position == len(pattern) {
+			return true
+		}
+
+		for i, reel := range reels {
+			if used[i] {
+				continue
+			}
+
+			nextRepeated := repeated
+			switch pattern[position] {
+			case SlotWildcard:
+			case SlotRepeated:
+				if repeated != "" && repeated != reel {
+					continue
+				}
+				nextRepeated = reel
+			default:
+				if pattern[position] != reel {
+					continue
+				}
+			}
+
+			used[i] = true
+			if match(position+1, nextRepeated) {
+				return true
+			}
+			used[i] = false
+		}
+		return false
+	}
+
+	return match(0, "")
 }
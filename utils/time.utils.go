@@ -0,0 +1,36 @@
+package utils
+
+import "time"
+
+// DateLayout is the DD-MM-YYYY key used for per-day records such as daily rewards
+const DateLayout = "02-01-2006"
+
+// bangkokLocation is fixed at UTC+7, Thailand has no daylight saving time
+var bangkokLocation = time.FixedZone("Asia/Bangkok", 7*60*60)
+
+// BangkokNow returns the current time in Asia/Bangkok regardless of the server time zone
+func BangkokNow() time.Time {
+	return time.Now().In(bangkokLocation)
+}
+
+// BangkokDayStart returns the midnight that started t's Asia/Bangkok day
+func BangkokDayStart(t time.Time) time.Time {
+	local := t.In(bangkokLocation)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, bangkokLocation)
+}
+
+// SecondsUntilBangkokMidnight returns how many seconds are left in t's Asia/Bangkok day, at least 1
+func SecondsUntilBangkokMidnight(t time.Time) int {
+	midnight := BangkokDayStart(t).AddDate(0, 0, 1)
+
+	seconds := int(midnight.Sub(t).Seconds())
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// BangkokDate returns the Asia/Bangkok calendar date of t as DD-MM-YYYY
+func BangkokDate(t time.Time) string {
+	return t.In(bangkokLocation).Format(DateLayout)
+}